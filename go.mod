@@ -1,3 +1,3 @@
 module github.com/northbright/luckydraw-go
 
-go 1.15
+go 1.23
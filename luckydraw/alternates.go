@@ -0,0 +1,102 @@
+package luckydraw
+
+import (
+	"time"
+)
+
+// DrawWithAlternates draws the prize like Draw and then draws up to alternates ranked backups
+// from the remaining participants. Winners of the prize are never its alternates, even in raffle mode.
+// Alternates are not winners, but they're excluded from other draws.
+// Existing alternates of the prize are replaced.
+func (d *Draw) DrawWithAlternates(prizeNo int, alternates int) (winners, backups []Participant, err error) {
+	defer func() { d.mutated(err) }()
+	defer func() { d.observeDraw(prizeNo, winners, err) }()
+	defer func(start time.Time) {
+		d.logOp("DrawWithAlternates", start, err, "prize_no", prizeNo, "winners", len(winners))
+	}(time.Now())
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.ensureInit()
+
+	if d.finalized {
+		return []Participant{}, []Participant{}, ErrFinalized
+	}
+
+	if alternates < 0 {
+		return []Participant{}, []Participant{}, ErrPrizeAmount
+	}
+
+	winners, err = d.drawPrize(prizeNo)
+	if err != nil {
+		return winners, []Participant{}, err
+	}
+
+	// Alternates are drawn from the remaining participants.
+	// Winners stay available in raffle mode, so they're removed explicitly.
+	won := participantSliceToMap(winners)
+	pool := []Participant{}
+	for _, p := range d.availableParticipants(prizeNo) {
+		if _, ok := won[p.ID]; !ok {
+			pool = append(pool, p)
+		}
+	}
+
+	delete(d.alternates, prizeNo)
+	backups = draw(d.rnd, alternates, pool)
+	d.alternates[prizeNo] = copyParticipants(backups)
+
+	return winners, backups, nil
+}
+
+// Alternates returns the ranked alternates of the prize.
+func (d *Draw) Alternates(prizeNo int) []Participant {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	alternates := make([]Participant, len(d.alternates[prizeNo]))
+	copy(alternates, d.alternates[prizeNo])
+	return alternates
+}
+
+// PromoteAlternate makes the top alternate of the prize a winner
+// to fill a slot of a revoked winner.
+// It returns ErrNoAlternates if the prize has no alternates
+// and ErrRedrawPrizeAmount if the winners of the prize are full.
+func (d *Draw) PromoteAlternate(prizeNo int) (winner Participant, err error) {
+	defer func() { d.mutated(err) }()
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.ensureInit()
+
+	if d.finalized {
+		return Participant{}, ErrFinalized
+	}
+
+	if _, ok := d.prizes[prizeNo]; !ok {
+		return Participant{}, ErrPrizeNo
+	}
+
+	if _, ok := d.winners[prizeNo]; !ok {
+		return Participant{}, ErrWinnersNotExistBeforeReDraw
+	}
+
+	if d.remainingSlots(prizeNo) == 0 {
+		return Participant{}, ErrRedrawPrizeAmount
+	}
+
+	alternates := d.alternates[prizeNo]
+	if len(alternates) == 0 {
+		return Participant{}, ErrNoAlternates
+	}
+
+	winner = alternates[0]
+	d.alternates[prizeNo] = alternates[1:]
+	d.winners[prizeNo] = append(d.winners[prizeNo], winner)
+	d.indexWinners(prizeNo)
+
+	return winner, nil
+}
@@ -0,0 +1,71 @@
+package luckydraw
+
+import (
+	"time"
+)
+
+// ApplySaveData replaces the state of the draw with the data (e.g. decoded in the background for hot reload).
+// The data is validated like Load before the lock is taken:
+// the version, the checksum, winners reference existing prizes and participants
+// and no prize has more winners than its amount.
+// Prize amounts and the numbers of prizes and participants are checked against the limits of the draw
+// (see SetMaxPrizeAmount, SetMaxPrizes and SetMaxParticipants).
+// Only if the data is valid, the state is replaced under a single lock,
+// so the draw is never observed half-applied. The random source is restored before the lock is taken,
+// so the critical section is brief.
+// Unlike Load, the draw is marked dirty and auto saved after applying, see SetAutoSave.
+// It returns ErrFinalized if the draw is finalized.
+// The maps and slices of data are used by the draw and must not be modified after the call.
+func (d *Draw) ApplySaveData(data SaveData) (err error) {
+	defer func() { d.mutated(err) }()
+	defer func(start time.Time) { d.logOp("ApplySaveData", start, err) }(time.Now())
+
+	if data.Version > SaveDataVersion {
+		return ErrSaveDataVersion
+	}
+
+	d.mutex.RLock()
+	legacy, seedKey, normalize := d.acceptLegacyChecksum, d.seedKey, d.idNormalizer
+	d.mutex.RUnlock()
+
+	if err := verifyChecksum(&data, legacy); err != nil {
+		return err
+	}
+
+	if err := unsealSeed(&data, seedKey); err != nil {
+		return err
+	}
+
+	// The checksum covers the IDs in the data, so they're normalized after it's verified.
+	if err := normalizeSaveData(&data, normalize); err != nil {
+		return err
+	}
+
+	if err := checkWinners(data.Prizes, data.Participants, data.Winners); err != nil {
+		return err
+	}
+
+	src, err := restoreRandSource(data.RandState)
+	if err != nil {
+		return err
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.finalized {
+		return ErrFinalized
+	}
+
+	// Make sure the data belongs to the draw.
+	if data.Name != d.name && !d.ignoreNameMismatch {
+		return ErrNameMismatch
+	}
+
+	if err := d.checkSaveDataLimits(&data); err != nil {
+		return err
+	}
+
+	d.restore(&data, src)
+	return nil
+}
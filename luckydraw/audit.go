@@ -0,0 +1,107 @@
+package luckydraw
+
+import (
+	"time"
+)
+
+// AuditEntry is an operation in the audit log, see AuditLog.
+type AuditEntry struct {
+	// Op is the name of the operation (e.g. "Draw" or "Revoke").
+	Op       string `json:"op"`
+	PrizeNo  int    `json:"prize_no"`
+	Operator string `json:"operator"`
+	Reason   string `json:"reason,omitempty"`
+	// IDs contains IDs of the drawn or revoked winners.
+	IDs []string  `json:"ids"`
+	At  time.Time `json:"at"`
+}
+
+// DrawBy draws the prize like Draw and records the operator and the reason in the audit log.
+// It returns ErrNoOperator if operator is empty.
+// Nothing is recorded if the draw fails.
+func (d *Draw) DrawBy(prizeNo int, operator, reason string) (winners []Participant, err error) {
+	defer func() { d.mutated(err) }()
+	defer func() { d.observeDraw(prizeNo, winners, err) }()
+	defer func(start time.Time) {
+		d.logOp("DrawBy", start, err, "prize_no", prizeNo, "winners", len(winners), "operator", operator)
+	}(time.Now())
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.ensureInit()
+
+	if d.finalized {
+		return []Participant{}, ErrFinalized
+	}
+
+	if operator == "" {
+		return []Participant{}, ErrNoOperator
+	}
+
+	winners, err = d.drawPrize(prizeNo)
+	if err != nil {
+		return winners, err
+	}
+
+	d.recordAudit("Draw", prizeNo, operator, reason, winners)
+	return winners, nil
+}
+
+// RevokeBy revokes the winners of the prize like RevokeWithReason
+// and records the operator and the reason in the audit log.
+// It returns ErrNoOperator if operator is empty.
+// Nothing is recorded if the revoke fails.
+func (d *Draw) RevokeBy(prizeNo int, revokedWinners []Participant, operator, reason string) (winners []Participant, err error) {
+	defer func() { d.mutated(err) }()
+	defer func() { d.observeRevoke(map[int][]Participant{prizeNo: revokedWinners}, err) }()
+	defer func(start time.Time) {
+		d.logOp("RevokeBy", start, err, "prize_no", prizeNo, "revoked", len(revokedWinners), "operator", operator)
+	}(time.Now())
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.ensureInit()
+
+	if d.finalized {
+		return []Participant{}, ErrFinalized
+	}
+
+	if operator == "" {
+		return []Participant{}, ErrNoOperator
+	}
+
+	winners, err = d.revoke(prizeNo, revokedWinners, reason)
+	if err != nil {
+		return winners, err
+	}
+
+	d.recordAudit("Revoke", prizeNo, operator, reason, revokedWinners)
+	return winners, nil
+}
+
+// AuditLog returns the audit log of operations done by DrawBy and RevokeBy in operation order.
+// The audit log is saved and loaded with the draw.
+func (d *Draw) AuditLog() []AuditEntry {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	log := make([]AuditEntry, len(d.audit))
+	for i, entry := range d.audit {
+		entry.IDs = append([]string{}, entry.IDs...)
+		log[i] = entry
+	}
+	return log
+}
+
+// recordAudit appends an operation with the participants to the audit log.
+// The caller should hold the mutex.
+func (d *Draw) recordAudit(op string, prizeNo int, operator, reason string, participants []Participant) {
+	ids := make([]string, 0, len(participants))
+	for _, p := range participants {
+		ids = append(ids, d.normalizeID(p.ID))
+	}
+
+	d.audit = append(d.audit, AuditEntry{op, prizeNo, operator, reason, ids, d.now()})
+}
@@ -0,0 +1,73 @@
+package luckydraw
+
+import (
+	"errors"
+	"time"
+)
+
+// SetAutoSave sets if the draw saves to the data file automatically
+// after each successful mutation (e.g. Draw, Redraw, Revoke, SetPrize, AddParticipant).
+// The data file is written after the mutex is released, so the lock is not held during I/O.
+// onError is called if auto save fails. It can be nil.
+func (d *Draw) SetAutoSave(autoSave bool, onError func(error)) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.autoSave = autoSave
+	d.onAutoSaveError = onError
+}
+
+// mutated is called by mutating methods after the mutex is released.
+// err is the error returned by the mutating method.
+// ErrPartialDraw is not a failure: the partial winners are recorded.
+// Pool exhaustions and changes of winners are reported even if the method fails,
+// see OnPoolExhausted and WatchWinners.
+func (d *Draw) mutated(err error) {
+	d.firePoolExhausted()
+
+	d.mutex.Lock()
+	d.publishWinners()
+	d.mutex.Unlock()
+
+	if err != nil && !errors.Is(err, ErrPartialDraw) {
+		return
+	}
+
+	d.observeUpdate()
+
+	d.mutex.Lock()
+	d.lastModified = d.now()
+	d.dirty = true
+	d.mutations++
+	autoSave, onError := d.autoSave, d.onAutoSaveError
+	d.mutex.Unlock()
+
+	if !autoSave {
+		return
+	}
+
+	if err := d.SaveToFile(); err != nil && onError != nil {
+		onError(err)
+	}
+}
+
+// LastModified returns the time of the last successful mutation of the draw in memory
+// (e.g. Draw, Revoke, SetPrize), which may be unsaved.
+// It's the zero time if the draw is not modified since it's created.
+// Unlike last_updated of the save data, it's not saved.
+func (d *Draw) LastModified() time.Time {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	return d.lastModified
+}
+
+// IsDirty reports if the draw has unsaved changes:
+// it's set by successful mutations and cleared by successful SaveToFile and Load.
+// Save and SaveCompact write to any writer, so they don't change it.
+func (d *Draw) IsDirty() bool {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	return d.dirty
+}
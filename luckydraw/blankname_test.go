@@ -0,0 +1,82 @@
+package luckydraw
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBlankNamesRoundTrip(t *testing.T) {
+	src := "id,name\n"
+	for i := 0; i < 20; i++ {
+		src += fmt.Sprintf("%03d,\n", i)
+	}
+
+	a := NewWithSeed("blank", 42)
+	if err := a.LoadParticipantsCSV(strings.NewReader(src)); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.SetPrize(1, "prize 1", 5, ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.Draw(1); err != nil {
+		t.Fatal(err)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := a.Save(buf); err != nil {
+		t.Fatal(err)
+	}
+	saved := buf.Bytes()
+
+	b := New("blank")
+	if err := b.Load(bytes.NewReader(saved)); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(a.Winners(1), b.Winners(1)) {
+		t.Errorf("winners after load differ: %v, %v", a.Winners(1), b.Winners(1))
+	}
+	for _, w := range b.Winners(1) {
+		if w.Name != "" {
+			t.Errorf("winner %s: got name %q, want blank", w.ID, w.Name)
+		}
+	}
+
+	// The checksum of blank names is stable.
+	buf.Reset()
+	if err := b.Save(buf); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(saved, buf.Bytes()) {
+		t.Errorf("save data differs after load:\n%s\n%s", saved, buf.Bytes())
+	}
+
+	buf.Reset()
+	if err := b.ExportParticipantsCSV(buf); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != src {
+		t.Errorf("ExportParticipantsCSV:\n%s\nwant:\n%s", buf.String(), src)
+	}
+
+	buf.Reset()
+	if err := b.ExportWinnersCSV(buf); err != nil {
+		t.Fatal(err)
+	}
+	records, err := csv.NewReader(buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 6 {
+		t.Fatalf("ExportWinnersCSV: got %d rows, want 6", len(records))
+	}
+	for _, record := range records[1:] {
+		if record[2] != "" {
+			t.Errorf("ExportWinnersCSV: got name %q, want blank", record[2])
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package luckydraw
+
+import (
+	"fmt"
+	"time"
+)
+
+// SetPrizeValue sets the value of each winner of the prize (e.g. in cents), see DrawWithinBudget.
+// It returns ErrPrizeValue if value is negative.
+func (d *Draw) SetPrizeValue(prizeNo int, value int) (err error) {
+	defer func() { d.mutated(err) }()
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.ensureInit()
+
+	if d.finalized {
+		return ErrFinalized
+	}
+
+	prize, ok := d.prizes[prizeNo]
+	if !ok {
+		return ErrPrizeNo
+	}
+
+	if value < 0 {
+		return ErrPrizeValue
+	}
+
+	prize.Value = value
+	d.prizes[prizeNo] = prize
+	return nil
+}
+
+// DrawWithinBudget draws the prizes in the given order (e.g. by priority)
+// while the total value of winners (value of the prize × winners) is within budget.
+// Each prize gets as many winners as the remaining budget allows, up to its amount.
+// It stops at the first prize which can't be fully paid (after drawing the affordable winners of it),
+// so the last drawn prize may be partially filled and can be filled later (e.g. by DrawOne).
+// A prize which can't afford any winner is not drawn.
+// All prizes are validated and drawn under a single lock: it validates that all prizes in order exist,
+// are drawable, have a positive value, have no winners and are not repeated, and nothing is drawn if any is invalid.
+// It stops at the first error and returns the results drawn so far in draw order.
+func (d *Draw) DrawWithinBudget(budget int, prizeOrder []int) (results []DrawResult, err error) {
+	results = []DrawResult{}
+
+	defer func() {
+		// The draw is unchanged if nothing is drawn.
+		if len(results) == 0 && err == nil {
+			return
+		}
+
+		for _, result := range results {
+			d.observeDraw(result.PrizeNo, result.Winners, nil)
+		}
+		if len(results) > 0 {
+			d.mutated(nil)
+		} else {
+			d.mutated(err)
+		}
+	}()
+	defer func(start time.Time) {
+		d.logOp("DrawWithinBudget", start, err, "prizes", len(results), "budget", budget)
+	}(time.Now())
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.ensureInit()
+
+	if d.finalized {
+		return results, ErrFinalized
+	}
+
+	seen := make(map[int]bool)
+	for _, prizeNo := range prizeOrder {
+		prize, ok := d.prizes[prizeNo]
+		if !ok || seen[prizeNo] {
+			return results, fmt.Errorf("prize no %d: %w", prizeNo, ErrPrizeNo)
+		}
+		seen[prizeNo] = true
+
+		if err := checkDrawable(d.effectiveAmount(prize)); err != nil {
+			return results, fmt.Errorf("prize no %d: %w", prizeNo, err)
+		}
+
+		if prize.Value <= 0 {
+			return results, fmt.Errorf("prize no %d: %w", prizeNo, ErrPrizeValue)
+		}
+
+		if _, ok := d.winners[prizeNo]; ok {
+			return results, fmt.Errorf("prize no %d: %w", prizeNo, ErrWinnersExistBeforeDraw)
+		}
+	}
+
+	for _, prizeNo := range prizeOrder {
+		result, spent, full, err := d.drawAffordable(prizeNo, budget)
+		if err != nil {
+			return results, fmt.Errorf("prize no %d: %w", prizeNo, err)
+		}
+
+		if len(result.Winners) > 0 {
+			results = append(results, result)
+			budget -= spent
+		}
+
+		if !full {
+			break
+		}
+	}
+
+	return results, nil
+}
+
+// drawAffordable draws as many winners of the prize as budget allows, up to the amount of the prize.
+// The prize should be validated by DrawWithinBudget.
+// It returns the total value of the winners as spent
+// and full reports if the full amount of the prize is affordable.
+// Nothing is recorded if no winner is affordable.
+// The caller should hold the mutex.
+func (d *Draw) drawAffordable(prizeNo int, budget int) (result DrawResult, spent int, full bool, err error) {
+	result = DrawResult{PrizeNo: prizeNo, Winners: []Participant{}, At: d.now()}
+
+	prize := d.resolvePercentAmount(prizeNo)
+	n := min(prize.Amount, budget/prize.Value)
+	if n < 1 {
+		return result, 0, false, nil
+	}
+
+	participants := d.availableParticipants(prizeNo)
+	if len(participants) == 0 {
+		d.notePoolExhausted(prizeNo, 0, n)
+		return result, 0, false, ErrNoAvailableParticipants
+	}
+
+	winners, err := d.selectWinners(d.rnd, prizeNo, n, participants)
+	if err != nil {
+		return result, 0, false, err
+	}
+	if len(winners) == 0 {
+		d.notePoolExhausted(prizeNo, 0, n)
+		return result, 0, false, ErrNoAvailableParticipants
+	}
+	if len(winners) < n {
+		d.notePoolExhausted(prizeNo, len(winners), n)
+	}
+
+	d.prizes[prizeNo] = prize
+	d.winners[prizeNo] = copyParticipants(winners)
+	d.indexWinners(prizeNo)
+	d.recordSequence(prizeNo, winners)
+
+	result.Winners = winners
+	result.Truncated = len(winners) < n
+	return result, prize.Value * len(winners), n == prize.Amount, nil
+}
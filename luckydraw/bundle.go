@@ -0,0 +1,83 @@
+package luckydraw
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"io"
+)
+
+// auditSeed is the seed file of the audit bundle.
+type auditSeed struct {
+	Commitment string `json:"commitment,omitempty"`
+	Seed       *int64 `json:"seed,omitempty"`
+}
+
+// ExportAuditBundle writes a zip file for audits which contains:
+//
+//	save.json: the data of the draw written by Save
+//	winners.csv: the winners written by ExportWinnersCSV
+//	seed.json: the commitment of the seed if it's committed by CommitSeed,
+//	  and the seed only after it can be revealed (see RevealSeed)
+//	sequences.json: the draw sequences of prizes keyed by prize no, see DrawSequence
+//
+// Each file is written by its own method, so the bundle should be exported
+// when no draw is in progress (e.g. after Finalize).
+// Like Save, it doesn't mark the draw clean, see IsDirty.
+func (d *Draw) ExportAuditBundle(w io.Writer) error {
+	zw := zip.NewWriter(w)
+
+	f, err := zw.Create("save.json")
+	if err != nil {
+		return err
+	}
+	if err := d.Save(f); err != nil {
+		return err
+	}
+
+	f, err = zw.Create("winners.csv")
+	if err != nil {
+		return err
+	}
+	if err := d.ExportWinnersCSV(f); err != nil {
+		return err
+	}
+
+	// A bundle exported in the middle of the event must not leak the committed seed.
+	seed := auditSeed{Commitment: d.Commitment()}
+	n, err := d.RevealSeed()
+	switch err {
+	case nil:
+		seed.Seed = &n
+	case ErrNoCommitment, ErrSeedNotRevealed:
+	default:
+		return err
+	}
+	if err := writeZipJSON(zw, "seed.json", seed); err != nil {
+		return err
+	}
+
+	d.mutex.Lock()
+	sequences := make(map[int][]string)
+	for prizeNo, ids := range d.sequences {
+		sequences[prizeNo] = append([]string{}, ids...)
+	}
+	d.mutex.Unlock()
+
+	if err := writeZipJSON(zw, "sequences.json", sequences); err != nil {
+		return err
+	}
+
+	return zw.Close()
+}
+
+// writeZipJSON writes v as indented JSON to the file in the zip.
+func writeZipJSON(zw *zip.Writer, name string, v interface{}) error {
+	f, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "    ")
+	return enc.Encode(v)
+}
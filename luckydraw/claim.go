@@ -0,0 +1,89 @@
+package luckydraw
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strconv"
+	"strings"
+)
+
+// SetClaimKey sets the secret key to sign and verify claim tokens.
+func (d *Draw) SetClaimKey(key []byte) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.claimKey = append([]byte(nil), key...)
+}
+
+// GenerateClaimToken generates a token which proves the participant won the prize.
+// The token contains the prize no and the ID and is signed by HMAC-SHA256 of
+// the event name, prize no and ID with the claim key, see SetClaimKey.
+// It can be verified offline by VerifyClaimToken.
+func (d *Draw) GenerateClaimToken(prizeNo int, id string) (string, error) {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	if len(d.claimKey) == 0 {
+		return "", ErrNoClaimKey
+	}
+
+	if _, ok := d.prizes[prizeNo]; !ok {
+		return "", ErrPrizeNo
+	}
+
+	id = d.normalizeID(id)
+	if !containsParticipant(d.winners[prizeNo], id) {
+		return "", ErrNotWinner
+	}
+
+	payload := strconv.Itoa(prizeNo) + ":" + id
+	return base64.RawURLEncoding.EncodeToString([]byte(payload)) + "." + d.claimMAC(prizeNo, id), nil
+}
+
+// VerifyClaimToken verifies the token generated by GenerateClaimToken and returns the prize no and the ID in it.
+// ok is false if the token is malformed, the signature doesn't match or the claim key is not set.
+// It checks the signature only, so revoked winners' tokens are still valid.
+func (d *Draw) VerifyClaimToken(token string) (prizeNo int, id string, ok bool) {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	if len(d.claimKey) == 0 {
+		return 0, "", false
+	}
+
+	encoded, mac, found := strings.Cut(token, ".")
+	if !found {
+		return 0, "", false
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return 0, "", false
+	}
+
+	no, id, found := strings.Cut(string(payload), ":")
+	if !found {
+		return 0, "", false
+	}
+
+	prizeNo, err = strconv.Atoi(no)
+	if err != nil {
+		return 0, "", false
+	}
+
+	if !hmac.Equal([]byte(mac), []byte(d.claimMAC(prizeNo, id))) {
+		return 0, "", false
+	}
+
+	return prizeNo, id, true
+}
+
+// claimMAC returns the hex encoded HMAC of the event name, prize no and ID.
+// The caller should hold the mutex.
+func (d *Draw) claimMAC(prizeNo int, id string) string {
+	h := hmac.New(sha256.New, d.claimKey)
+	h.Write([]byte(strconv.Quote(d.name) + "\n" + strconv.Itoa(prizeNo) + "\n" + strconv.Quote(id)))
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,25 @@
+package luckydraw
+
+import (
+	"time"
+)
+
+// SetClock sets the function which returns the current time
+// for timestamps (e.g. last updated time of Save, revoke time).
+// It's useful to get fixed timestamps in tests or to use a trusted clock.
+// nil (default) means time.Now.
+func (d *Draw) SetClock(clock func() time.Time) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.clock = clock
+}
+
+// now returns the current time of the clock.
+// The caller should hold the mutex.
+func (d *Draw) now() time.Time {
+	if d.clock == nil {
+		return time.Now()
+	}
+	return d.clock()
+}
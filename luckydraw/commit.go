@@ -0,0 +1,235 @@
+package luckydraw
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	mathrand "math/rand"
+	"strconv"
+)
+
+// CommitSeed generates a secret seed for the following draws
+// and returns the commitment of the seed, which can be published before drawing.
+// After drawing, RevealSeed returns the seed, so anyone can check it with VerifyCommitment
+// and recompute the winners with NewWithSeed.
+// The commitment is the hex encoded SHA-256 hash of the seed in decimal.
+// Until the seed can be revealed, the save data contains the seed encrypted by the seed key only,
+// so a seed key must be set by SetSeedKey to save the draw.
+// It returns ErrWinnersExist if any winner is drawn, because the winners were not drawn with the seed,
+// and ErrSeedCommitted if the seed is already committed, so the seed can't be regenerated
+// until the commitment is reset by ResetCommitment.
+func (d *Draw) CommitSeed() (commitment string, err error) {
+	defer func() { d.mutated(err) }()
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.ensureInit()
+
+	if d.finalized {
+		return "", ErrFinalized
+	}
+
+	if len(d.winners) > 0 {
+		return "", ErrWinnersExist
+	}
+
+	if d.commitment != "" {
+		return "", ErrSeedCommitted
+	}
+
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	seed := int64(binary.BigEndian.Uint64(buf))
+
+	d.src = newRandSource(d.src.algorithm, seed)
+	d.rnd = mathrand.New(d.src)
+	d.commitment = makeCommitment(seed)
+
+	return d.commitment, nil
+}
+
+// ResetCommitment discards the committed seed and reseeds the random source with a new random seed,
+// so the seed can be committed again by CommitSeed (e.g. the published commitment is lost before drawing).
+// The discarded commitment should be withdrawn publicly.
+// It returns ErrWinnersExist if any winner is drawn and ErrNoCommitment if the seed is not committed.
+func (d *Draw) ResetCommitment() (err error) {
+	defer func() { d.mutated(err) }()
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.ensureInit()
+
+	if d.finalized {
+		return ErrFinalized
+	}
+
+	if len(d.winners) > 0 {
+		return ErrWinnersExist
+	}
+
+	if d.commitment == "" {
+		return ErrNoCommitment
+	}
+
+	d.src = newRandSource(d.src.algorithm, newSeed())
+	d.rnd = mathrand.New(d.src)
+	d.commitment = ""
+	return nil
+}
+
+// Commitment returns the commitment of the seed returned by CommitSeed.
+// It's empty if the seed is not committed.
+func (d *Draw) Commitment() string {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	return d.commitment
+}
+
+// RevealSeed returns the seed committed by CommitSeed.
+// It returns ErrNoCommitment if the seed is not committed
+// and ErrSeedNotRevealed if the draws are not done yet:
+// the seed can be revealed after the draw is finalized (see Finalize)
+// or all drawable prizes have winners.
+func (d *Draw) RevealSeed() (int64, error) {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	if d.commitment == "" {
+		return 0, ErrNoCommitment
+	}
+	if !d.seedRevealable() {
+		return 0, ErrSeedNotRevealed
+	}
+	return d.src.seed, nil
+}
+
+// seedRevealable reports if the committed seed can be revealed:
+// the draw is finalized or all drawable prizes have winners.
+// The caller should hold the mutex.
+func (d *Draw) seedRevealable() bool {
+	if d.finalized {
+		return true
+	}
+
+	drawn := false
+	for no, prize := range d.prizes {
+		if checkDrawable(d.effectiveAmount(prize)) != nil {
+			continue
+		}
+		if len(d.winners[no]) == 0 {
+			return false
+		}
+		drawn = true
+	}
+	return drawn
+}
+
+// SetSeedKey sets the secret key to encrypt the committed seed in the save data
+// until the seed can be revealed, see CommitSeed and RevealSeed.
+// Save returns ErrSeedKey if the seed needs to be encrypted and the key is not set,
+// and Load returns ErrSeedKey if the data contains an encrypted seed and the key is missing or incorrect.
+func (d *Draw) SetSeedKey(key []byte) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.seedKey = append([]byte(nil), key...)
+}
+
+// sealSeed replaces the seed of the random state in data with the encrypted seed
+// if the seed is committed and can't be revealed yet.
+// The caller should hold the mutex.
+func (d *Draw) sealSeed(data *SaveData) error {
+	if d.commitment == "" || d.seedRevealable() {
+		return nil
+	}
+
+	if len(d.seedKey) == 0 {
+		return ErrSeedKey
+	}
+
+	aead, err := newSeedAEAD(d.seedKey)
+	if err != nil {
+		return err
+	}
+
+	plaintext := make([]byte, 8)
+	binary.BigEndian.PutUint64(plaintext, uint64(data.RandState.Seed))
+	nonce := seedNonce(d.commitment, aead.NonceSize())
+	data.SealedSeed = base64.StdEncoding.EncodeToString(aead.Seal(nil, nonce, plaintext, []byte(d.commitment)))
+	data.RandState.Seed = 0
+	return nil
+}
+
+// unsealSeed decrypts the sealed seed of data by the key and restores it to the random state of data.
+// It returns ErrSeedKey if the key is missing or incorrect.
+func unsealSeed(data *SaveData, key []byte) error {
+	if data.SealedSeed == "" {
+		return nil
+	}
+
+	if len(key) == 0 || data.RandState == nil {
+		return ErrSeedKey
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(data.SealedSeed)
+	if err != nil {
+		return ErrSeedKey
+	}
+
+	aead, err := newSeedAEAD(key)
+	if err != nil {
+		return err
+	}
+
+	plaintext, err := aead.Open(nil, seedNonce(data.Commitment, aead.NonceSize()), sealed, []byte(data.Commitment))
+	if err != nil || len(plaintext) != 8 {
+		return ErrSeedKey
+	}
+
+	seed := int64(binary.BigEndian.Uint64(plaintext))
+	if !VerifyCommitment(data.Commitment, seed) {
+		return ErrSeedKey
+	}
+
+	// Copy the state, so the caller's data is not modified.
+	state := *data.RandState
+	state.Seed = seed
+	data.RandState = &state
+	data.SealedSeed = ""
+	return nil
+}
+
+// newSeedAEAD returns AES-256-GCM with the SHA-256 hash of the key.
+func newSeedAEAD(key []byte) (cipher.AEAD, error) {
+	k := sha256.Sum256(key)
+	block, err := aes.NewCipher(k[:])
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// seedNonce derives the nonce from the commitment, so the output of Save is deterministic.
+// A nonce is only used for the seed of its commitment, so it's never reused for another plaintext.
+func seedNonce(commitment string, size int) []byte {
+	sum := sha256.Sum256([]byte("seed nonce:" + commitment))
+	return sum[:size]
+}
+
+// VerifyCommitment reports if the commitment is the commitment of the seed.
+func VerifyCommitment(commitment string, seed int64) bool {
+	return makeCommitment(seed) == commitment
+}
+
+func makeCommitment(seed int64) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(strconv.FormatInt(seed, 10))))
+}
@@ -0,0 +1,120 @@
+package luckydraw
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestCommitRevealSeed(t *testing.T) {
+	d := newSeededDraw(t, 1)
+
+	commitment, err := d.CommitSeed()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.RevealSeed(); err != ErrSeedNotRevealed {
+		t.Errorf("RevealSeed before the draws: got %v, want ErrSeedNotRevealed", err)
+	}
+	if _, err := d.CommitSeed(); err != ErrSeedCommitted {
+		t.Errorf("second CommitSeed: got %v, want ErrSeedCommitted", err)
+	}
+
+	for no := 1; no <= 3; no++ {
+		if _, err := d.Draw(no); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	seed, err := d.RevealSeed()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !VerifyCommitment(commitment, seed) {
+		t.Errorf("seed %d doesn't match the commitment %s", seed, commitment)
+	}
+
+	// The winners are recomputed with the revealed seed.
+	r := newSeededDraw(t, seed)
+	if err := r.SetRandAlgorithm(d.RandAlgorithm()); err != nil {
+		t.Fatal(err)
+	}
+	for no := 1; no <= 3; no++ {
+		if _, err := r.Draw(no); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !reflect.DeepEqual(d.AllWinners(), r.AllWinners()) {
+		t.Errorf("winners recomputed with the seed differ: %v, %v", d.AllWinners(), r.AllWinners())
+	}
+}
+
+func TestCommitSeedAfterDraw(t *testing.T) {
+	d := newSeededDraw(t, 1)
+	if _, err := d.Draw(1); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := d.CommitSeed(); err != ErrWinnersExist {
+		t.Errorf("got %v, want ErrWinnersExist", err)
+	}
+	if d.Commitment() != "" {
+		t.Errorf("got commitment %s", d.Commitment())
+	}
+}
+
+func TestResetCommitment(t *testing.T) {
+	d := newSeededDraw(t, 1)
+
+	if err := d.ResetCommitment(); err != ErrNoCommitment {
+		t.Errorf("got %v, want ErrNoCommitment", err)
+	}
+
+	first, err := d.CommitSeed()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := d.ResetCommitment(); err != nil {
+		t.Fatal(err)
+	}
+	second, err := d.CommitSeed()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first == second {
+		t.Errorf("got the same commitment after reset")
+	}
+
+	if _, err := d.Draw(1); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.ResetCommitment(); err != ErrWinnersExist {
+		t.Errorf("after draw: got %v, want ErrWinnersExist", err)
+	}
+}
+
+func TestCommittedSeedSealed(t *testing.T) {
+	d := newSeededDraw(t, 1)
+	d.SetSeedKey([]byte("key"))
+	if _, err := d.CommitSeed(); err != nil {
+		t.Fatal(err)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := d.Save(buf); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := New("seed").Load(bytes.NewReader(buf.Bytes())); err != ErrSeedKey {
+		t.Errorf("Load without the key: got %v, want ErrSeedKey", err)
+	}
+
+	b := New("seed")
+	b.SetSeedKey([]byte("key"))
+	if err := b.Load(bytes.NewReader(buf.Bytes())); err != nil {
+		t.Fatal(err)
+	}
+	if b.Commitment() != d.Commitment() {
+		t.Errorf("commitment differs after load")
+	}
+}
@@ -0,0 +1,50 @@
+package luckydraw
+
+// ConflictPolicy is the policy of the participants loaders for rows with the same ID
+// (e.g. "Bob" and "Bob Smith" from different registration sources).
+type ConflictPolicy int
+
+const (
+	// ConflictError makes the loaders return ErrParticipantExists. It's the default.
+	ConflictError ConflictPolicy = iota
+	// ConflictKeepFirst keeps the first row.
+	ConflictKeepFirst
+	// ConflictKeepLast keeps the last row.
+	ConflictKeepLast
+	// ConflictResolve calls the resolver to reconcile the rows.
+	ConflictResolve
+)
+
+// ConflictResolver returns the participant reconciled from the existing row and the incoming row with the same ID.
+type ConflictResolver func(existing, incoming Participant) Participant
+
+// SetConflictPolicy sets the policy of the participants loaders (e.g. LoadParticipantsCSV, AppendParticipantsCSV)
+// for rows with the same ID in a file.
+// resolve is used by ConflictResolve and the ID of the participant it returns is ignored.
+// It's called with the mutex held, so it must not call methods of the draw.
+// In raffle mode, rows with the same ID are tickets and the policy is not applied.
+func (d *Draw) SetConflictPolicy(policy ConflictPolicy, resolve ConflictResolver) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.conflictPolicy = policy
+	d.conflictResolver = resolve
+}
+
+// resolveConflict returns the participant to keep for the existing and incoming rows with the same ID.
+// The caller should hold the mutex.
+func (d *Draw) resolveConflict(existing, incoming Participant) (Participant, error) {
+	switch d.conflictPolicy {
+	case ConflictKeepFirst:
+		return existing, nil
+	case ConflictKeepLast:
+		return incoming, nil
+	case ConflictResolve:
+		if d.conflictResolver != nil {
+			p := d.conflictResolver(existing, incoming)
+			p.ID = existing.ID
+			return p, nil
+		}
+	}
+	return Participant{}, ErrParticipantExists
+}
@@ -0,0 +1,70 @@
+package luckydraw
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestCheckWinners(t *testing.T) {
+	participants := map[string]Participant{}
+	for i := 0; i < 20; i++ {
+		id := fmt.Sprintf("%03d", i)
+		participants[id] = Participant{ID: id}
+	}
+	winners := func(n int) []Participant {
+		s := []Participant{}
+		for i := 0; i < n; i++ {
+			s = append(s, participants[fmt.Sprintf("%03d", i)])
+		}
+		return s
+	}
+
+	for _, c := range []struct {
+		name    string
+		prizes  map[int]Prize
+		winners map[int][]Participant
+		ok      bool
+	}{
+		{"full", map[int]Prize{1: {No: 1, Amount: 3}}, map[int][]Participant{1: winners(3)}, true},
+		{"over amount", map[int]Prize{1: {No: 1, Amount: 3}}, map[int][]Participant{1: winners(4)}, false},
+		// 10% of 20 participants is 2.
+		{"unresolved percent", map[int]Prize{1: {No: 1, Percent: 10}}, map[int][]Participant{1: winners(2)}, true},
+		{"over unresolved percent", map[int]Prize{1: {No: 1, Percent: 10}}, map[int][]Participant{1: winners(3)}, false},
+		{"unknown prize", map[int]Prize{}, map[int][]Participant{1: winners(1)}, false},
+		{"unknown winner", map[int]Prize{1: {No: 1, Amount: 3}}, map[int][]Participant{1: {{ID: "x"}}}, false},
+	} {
+		err := checkWinners(c.prizes, participants, c.winners)
+		if c.ok && err != nil {
+			t.Errorf("%s: got %v", c.name, err)
+		}
+		if !c.ok && !errors.Is(err, ErrInconsistentState) {
+			t.Errorf("%s: got %v, want ErrInconsistentState", c.name, err)
+		}
+	}
+}
+
+func TestSaveAfterPercentAndRaffleDraws(t *testing.T) {
+	for _, raffle := range []bool{false, true} {
+		d := newSeededDraw(t, 42)
+		if err := d.SetRaffleMode(raffle); err != nil {
+			t.Fatal(err)
+		}
+		if err := d.SetPrizePercent(1, 10); err != nil {
+			t.Fatal(err)
+		}
+		for no := 1; no <= 3; no++ {
+			if _, err := d.Draw(no); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if err := d.Save(&discard{}); err != nil {
+			t.Errorf("raffle %v: Save: %v", raffle, err)
+		}
+	}
+}
+
+type discard struct{}
+
+func (discard) Write(p []byte) (int, error) { return len(p), nil }
@@ -0,0 +1,47 @@
+package luckydraw
+
+// NonWinners returns the participants who haven't won any prize
+// (e.g. for consolation prizes after the main prizes are drawn).
+// Excluded participants are included. Participants are sorted by ID.
+func (d *Draw) NonWinners() []Participant {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	participants := copyParticipantMap(d.participants)
+	removeWinners(participants, d.winners)
+	return participantMapToSlice(participants)
+}
+
+// SetPrizeConsolation sets if the prize is a consolation prize
+// which is drawn from participants who haven't won any prize, see NonWinners.
+// Other availability rules (e.g. exclusions, eligibility) still apply.
+func (d *Draw) SetPrizeConsolation(prizeNo int, consolation bool) (err error) {
+	defer func() { d.mutated(err) }()
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.ensureInit()
+
+	if d.finalized {
+		return ErrFinalized
+	}
+
+	prize, ok := d.prizes[prizeNo]
+	if !ok {
+		return ErrPrizeNo
+	}
+
+	prize.Consolation = consolation
+	d.prizes[prizeNo] = prize
+	return nil
+}
+
+// removeWinners removes winners of all prizes from participants.
+func removeWinners(participants map[string]Participant, winners map[int][]Participant) {
+	for _, s := range winners {
+		for _, winner := range s {
+			delete(participants, winner.ID)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package luckydraw
+
+import (
+	"bufio"
+	"encoding/csv"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// CSVOptions are the options of CSV exports (e.g. ExportParticipantsCSV, ExportWinnersCSV).
+type CSVOptions struct {
+	// Comma is the field delimiter. 0 means ','.
+	Comma rune
+	// UseCRLF makes rows end with \r\n instead of \n.
+	// Newlines in fields are written as \r\n too.
+	UseCRLF bool
+	// AlwaysQuote quotes all fields instead of only the fields which need quotes.
+	AlwaysQuote bool
+	// ShuffleParticipants makes ExportParticipantsCSV write participants in a shuffled order
+	// instead of by ID, so the published list doesn't reveal the registration order.
+	ShuffleParticipants bool
+	// ShuffleSeed is the seed of the shuffled order, which is independent of the seed of the draw,
+	// so the published order reveals nothing about the winners.
+	// The order is the same for the same seed and participants.
+	// 0 means a new random seed from crypto/rand for each export.
+	ShuffleSeed int64
+}
+
+// SetCSVExportOptions sets the options of CSV exports for downstream systems
+// which require CRLF line endings or quoted fields.
+// Fields containing delimiters, quotes or newlines are always quoted, so they can be read back by the CSV loaders.
+// It returns ErrCSVDelimiter if the delimiter is a quote, a newline or an invalid rune.
+func (d *Draw) SetCSVExportOptions(opts CSVOptions) error {
+	if opts.Comma == '"' || opts.Comma == '\r' || opts.Comma == '\n' ||
+		(opts.Comma != 0 && !utf8.ValidRune(opts.Comma)) || opts.Comma == utf8.RuneError {
+		return ErrCSVDelimiter
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.csvOptions = opts
+	return nil
+}
+
+// csvWriter writes CSV rows like csv.Writer.
+type csvWriter interface {
+	Write(record []string) error
+	Flush()
+	Error() error
+}
+
+// newCSVWriter returns a CSV writer with the options.
+func newCSVWriter(w io.Writer, opts CSVOptions) csvWriter {
+	comma := opts.Comma
+	if comma == 0 {
+		comma = ','
+	}
+
+	if opts.AlwaysQuote {
+		return &quotingCSVWriter{w: bufio.NewWriter(w), comma: comma, useCRLF: opts.UseCRLF}
+	}
+
+	writer := csv.NewWriter(w)
+	writer.Comma = comma
+	writer.UseCRLF = opts.UseCRLF
+	return writer
+}
+
+// quotingCSVWriter is a CSV writer which quotes all fields.
+// Quotes and newlines in fields are written like csv.Writer.
+type quotingCSVWriter struct {
+	w       *bufio.Writer
+	comma   rune
+	useCRLF bool
+}
+
+func (qw *quotingCSVWriter) Write(record []string) error {
+	for i, field := range record {
+		if i > 0 {
+			if _, err := qw.w.WriteRune(qw.comma); err != nil {
+				return err
+			}
+		}
+
+		field = strings.ReplaceAll(field, `"`, `""`)
+		if qw.useCRLF {
+			field = strings.ReplaceAll(field, "\r", "")
+			field = strings.ReplaceAll(field, "\n", "\r\n")
+		}
+
+		if _, err := qw.w.WriteString(`"` + field + `"`); err != nil {
+			return err
+		}
+	}
+
+	eol := "\n"
+	if qw.useCRLF {
+		eol = "\r\n"
+	}
+	_, err := qw.w.WriteString(eol)
+	return err
+}
+
+func (qw *quotingCSVWriter) Flush() {
+	qw.w.Flush()
+}
+
+func (qw *quotingCSVWriter) Error() error {
+	_, err := qw.w.Write(nil)
+	return err
+}
@@ -0,0 +1,55 @@
+package luckydraw
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"math/rand"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// DeterministicSeed returns the seed derived from the pool and the prize no for DrawDeterministic.
+// It's the first 8 bytes (big endian) of the SHA-256 hash of the IDs of the pool sorted in ascending order,
+// each followed by "\n", and the prize no in decimal.
+func DeterministicSeed(pool []Participant, prizeNo int) int64 {
+	ids := []string{}
+	for _, p := range pool {
+		ids = append(ids, p.ID)
+	}
+	sort.Strings(ids)
+
+	h := sha256.New()
+	for _, id := range ids {
+		h.Write([]byte(id + "\n"))
+	}
+	h.Write([]byte(strconv.Itoa(prizeNo)))
+
+	return int64(binary.BigEndian.Uint64(h.Sum(nil)))
+}
+
+// DrawDeterministic draws the prize like DrawWithSeed with the seed derived from
+// the available participants and the prize no, see DeterministicSeed.
+// The winners are a pure function of the available participants and the prize,
+// so anyone can recompute them without a stored seed:
+//
+//	VerifyDraw(DeterministicSeed(pool, prizeNo), pool, amount, winners)
+func (d *Draw) DrawDeterministic(prizeNo int) (winners []Participant, err error) {
+	defer func() { d.mutated(err) }()
+	defer func() { d.observeDraw(prizeNo, winners, err) }()
+	defer func(start time.Time) {
+		d.logOp("DrawDeterministic", start, err, "prize_no", prizeNo, "winners", len(winners))
+	}(time.Now())
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.ensureInit()
+
+	if d.finalized {
+		return []Participant{}, ErrFinalized
+	}
+
+	seed := DeterministicSeed(d.availableParticipants(prizeNo), prizeNo)
+	return d.drawPrizeWith(rand.New(rand.NewSource(seed)), prizeNo, nil)
+}
@@ -0,0 +1,134 @@
+package luckydraw
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+)
+
+// SaveDiff is the differences between two save files reported by DiffSaveFiles.
+// Changes are from the first file to the second one.
+type SaveDiff struct {
+	PrizesAdded         []int    `json:"prizes_added"`
+	PrizesRemoved       []int    `json:"prizes_removed"`
+	PrizesChanged       []int    `json:"prizes_changed"`
+	ParticipantsAdded   []string `json:"participants_added"`
+	ParticipantsRemoved []string `json:"participants_removed"`
+	ParticipantsChanged []string `json:"participants_changed"`
+	// WinnersAdded contains IDs of winners only in the second file keyed by prize no.
+	WinnersAdded map[int][]string `json:"winners_added"`
+	// WinnersRemoved contains IDs of winners only in the first file keyed by prize no.
+	WinnersRemoved map[int][]string `json:"winners_removed"`
+}
+
+// Equal reports if there's no difference.
+func (diff *SaveDiff) Equal() bool {
+	return len(diff.PrizesAdded) == 0 && len(diff.PrizesRemoved) == 0 && len(diff.PrizesChanged) == 0 &&
+		len(diff.ParticipantsAdded) == 0 && len(diff.ParticipantsRemoved) == 0 && len(diff.ParticipantsChanged) == 0 &&
+		len(diff.WinnersAdded) == 0 && len(diff.WinnersRemoved) == 0
+}
+
+// DiffSaveFiles compares the prizes, participants and winners of two save files.
+// Formatting and the order of winners are ignored.
+// Checksums of both files are verified and ErrChecksum is returned if one is tampered.
+func DiffSaveFiles(a, b string) (*SaveDiff, error) {
+	dataA, err := readSaveFile(a)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", a, err)
+	}
+
+	dataB, err := readSaveFile(b)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", b, err)
+	}
+
+	diff := &SaveDiff{
+		PrizesAdded:         []int{},
+		PrizesRemoved:       []int{},
+		PrizesChanged:       []int{},
+		ParticipantsAdded:   []string{},
+		ParticipantsRemoved: []string{},
+		ParticipantsChanged: []string{},
+		WinnersAdded:        make(map[int][]string),
+		WinnersRemoved:      make(map[int][]string),
+	}
+
+	for no, prize := range dataA.Prizes {
+		other, ok := dataB.Prizes[no]
+		if !ok {
+			diff.PrizesRemoved = append(diff.PrizesRemoved, no)
+		} else if !reflect.DeepEqual(prize, other) {
+			diff.PrizesChanged = append(diff.PrizesChanged, no)
+		}
+	}
+	for no := range dataB.Prizes {
+		if _, ok := dataA.Prizes[no]; !ok {
+			diff.PrizesAdded = append(diff.PrizesAdded, no)
+		}
+	}
+
+	for id, p := range dataA.Participants {
+		other, ok := dataB.Participants[id]
+		if !ok {
+			diff.ParticipantsRemoved = append(diff.ParticipantsRemoved, id)
+		} else if !reflect.DeepEqual(p, other) {
+			diff.ParticipantsChanged = append(diff.ParticipantsChanged, id)
+		}
+	}
+	for id := range dataB.Participants {
+		if _, ok := dataA.Participants[id]; !ok {
+			diff.ParticipantsAdded = append(diff.ParticipantsAdded, id)
+		}
+	}
+
+	for no, ids := range diffWinnerIDs(dataA.Winners, dataB.Winners) {
+		diff.WinnersRemoved[no] = ids
+	}
+	for no, ids := range diffWinnerIDs(dataB.Winners, dataA.Winners) {
+		diff.WinnersAdded[no] = ids
+	}
+
+	sort.Ints(diff.PrizesAdded)
+	sort.Ints(diff.PrizesRemoved)
+	sort.Ints(diff.PrizesChanged)
+	sort.Strings(diff.ParticipantsAdded)
+	sort.Strings(diff.ParticipantsRemoved)
+	sort.Strings(diff.ParticipantsChanged)
+
+	return diff, nil
+}
+
+// diffWinnerIDs returns sorted IDs of winners in a but not in b keyed by prize no.
+func diffWinnerIDs(a, b map[int][]Participant) map[int][]string {
+	m := make(map[int][]string)
+	for no, winners := range a {
+		for _, winner := range winners {
+			if !containsParticipant(b[no], winner.ID) {
+				m[no] = append(m[no], winner.ID)
+			}
+		}
+		sort.Strings(m[no])
+	}
+	return m
+}
+
+// readSaveFile reads the save file and verifies its checksum.
+func readSaveFile(file string) (*SaveData, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	data := SaveData{}
+	if err := decodeSaveData(f, &data); err != nil {
+		return nil, err
+	}
+
+	if err := verifyChecksum(&data, false); err != nil {
+		return nil, err
+	}
+
+	return &data, nil
+}
@@ -0,0 +1,79 @@
+package luckydraw
+
+import (
+	"fmt"
+)
+
+// DrawAll draws all prizes which have no winners yet, sorted by prize no.
+// Display-only prizes (amount 0) are skipped.
+// progress is called after each prize is drawn with the number of drawn prizes and the total.
+// It's called without holding the mutex, so it's safe to call methods of the draw in it.
+// progress can be nil.
+// It stops at the first error and returns the winners drawn so far.
+func (d *Draw) DrawAll(descOrder bool, progress func(done, total int)) (map[int][]Participant, error) {
+	results := make(map[int][]Participant)
+
+	d.mutex.Lock()
+	prizeNos := []int{}
+	for _, prize := range prizeMapToSlice(d.prizes, descOrder) {
+		if _, ok := d.winners[prize.No]; !ok && d.effectiveAmount(prize) > 0 {
+			prizeNos = append(prizeNos, prize.No)
+		}
+	}
+	d.mutex.Unlock()
+
+	for i, prizeNo := range prizeNos {
+		winners, err := d.Draw(prizeNo)
+		if err != nil {
+			return results, err
+		}
+		results[prizeNo] = winners
+
+		if progress != nil {
+			progress(i+1, len(prizeNos))
+		}
+	}
+
+	return results, nil
+}
+
+// DrawAllInOrder draws the prizes in exactly the given order (e.g. the stage program).
+// Prizes not in order are not drawn.
+// Before drawing, it validates that all prizes in order exist, are drawable, have no winners
+// and are not repeated, and nothing is drawn if any is invalid.
+// It stops at the first error and returns the winners drawn so far in draw order.
+func (d *Draw) DrawAllInOrder(order []int) ([]PrizeWinners, error) {
+	results := []PrizeWinners{}
+
+	d.mutex.Lock()
+	seen := make(map[int]bool)
+	for _, prizeNo := range order {
+		prize, ok := d.prizes[prizeNo]
+		if !ok || seen[prizeNo] {
+			d.mutex.Unlock()
+			return results, fmt.Errorf("prize no %d: %w", prizeNo, ErrPrizeNo)
+		}
+		seen[prizeNo] = true
+
+		if err := checkDrawable(d.effectiveAmount(prize)); err != nil {
+			d.mutex.Unlock()
+			return results, fmt.Errorf("prize no %d: %w", prizeNo, err)
+		}
+
+		if _, ok := d.winners[prizeNo]; ok {
+			d.mutex.Unlock()
+			return results, fmt.Errorf("prize no %d: %w", prizeNo, ErrWinnersExistBeforeDraw)
+		}
+	}
+	d.mutex.Unlock()
+
+	for _, prizeNo := range order {
+		winners, err := d.Draw(prizeNo)
+		if err != nil {
+			return results, fmt.Errorf("prize no %d: %w", prizeNo, err)
+		}
+		results = append(results, PrizeWinners{prizeNo, winners})
+	}
+
+	return results, nil
+}
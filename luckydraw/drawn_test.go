@@ -0,0 +1,58 @@
+package luckydraw
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestDrawNSaveLoad(t *testing.T) {
+	a := newSeededDraw(t, 42)
+	if err := a.SetPrizePercent(2, 10); err != nil {
+		t.Fatal(err)
+	}
+
+	// Prize 1 has amount 3 and prize 2 has a percent amount.
+	for _, c := range []struct{ prizeNo, n int }{{1, 5}, {2, 2}} {
+		winners, err := a.DrawN(c.prizeNo, c.n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(winners) != c.n {
+			t.Errorf("DrawN(%d, %d): got %d winners", c.prizeNo, c.n, len(winners))
+		}
+		if prize, _ := a.LookupPrize(c.prizeNo); prize.Amount != c.n {
+			t.Errorf("DrawN(%d, %d): got amount %d", c.prizeNo, c.n, prize.Amount)
+		}
+	}
+
+	buf := &bytes.Buffer{}
+	if err := a.Save(buf); err != nil {
+		t.Fatal(err)
+	}
+
+	b := New("seed")
+	if err := b.Load(buf); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(a.AllWinners(), b.AllWinners()) {
+		t.Errorf("winners differ after load: %v, %v", a.AllWinners(), b.AllWinners())
+	}
+}
+
+func TestDrawNNotEnoughParticipants(t *testing.T) {
+	d := newSeededDraw(t, 42)
+
+	if _, err := d.DrawN(1, 51); err != ErrNotEnoughParticipants {
+		t.Errorf("got %v, want ErrNotEnoughParticipants", err)
+	}
+	if winners := d.Winners(1); len(winners) != 0 {
+		t.Errorf("got winners %v", winners)
+	}
+	if prize, _ := d.LookupPrize(1); prize.Amount != 3 {
+		t.Errorf("got amount %d, want 3", prize.Amount)
+	}
+	if _, err := d.DrawN(99, 1); err != ErrPrizeNo {
+		t.Errorf("got %v, want ErrPrizeNo", err)
+	}
+}
@@ -0,0 +1,140 @@
+package luckydraw
+
+import (
+	"sort"
+)
+
+// SetPrizeEligibility sets the predicate of eligible participants of the prize
+// (e.g. IDs starting with "E", registered before a date).
+// Only participants for whom pred returns true are available for the prize.
+// nil removes the predicate.
+// pred is called with the mutex held, so it must not call methods of the draw.
+// The predicate is not saved, so it must be set again after Load.
+func (d *Draw) SetPrizeEligibility(prizeNo int, pred func(Participant) bool) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if _, ok := d.prizes[prizeNo]; !ok {
+		return ErrPrizeNo
+	}
+
+	d.invalidatePool()
+
+	if pred == nil {
+		delete(d.eligibility, prizeNo)
+		return nil
+	}
+
+	if d.eligibility == nil {
+		d.eligibility = make(map[int]func(Participant) bool)
+	}
+	d.eligibility[prizeNo] = pred
+	return nil
+}
+
+// NeverEligible returns the participants who can't win any prize
+// because they're excluded (see Exclude) or rejected by the eligibility predicates of all drawable prizes,
+// so configuration mistakes (e.g. a typo of a group) can be found before the event.
+// Winners are not considered. Participants are sorted by ID.
+func (d *Draw) NeverEligible() []Participant {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	never := make(map[string]Participant)
+	for id, p := range d.participants {
+		if d.excluded[id] {
+			never[id] = p
+			continue
+		}
+
+		eligible := false
+		for no, prize := range d.prizes {
+			if checkDrawable(d.effectiveAmount(prize)) != nil {
+				continue
+			}
+			if pred := d.eligibility[no]; pred == nil || pred(p) {
+				eligible = true
+				break
+			}
+		}
+
+		if !eligible {
+			never[id] = p
+		}
+	}
+
+	return participantMapToSlice(never)
+}
+
+// EligiblePrizes returns the prizes the participant can still win:
+// the prize is drawable and not full, and the participant is in its available pool
+// (not excluded, passes the eligibility predicate and is not a winner unless multi-win allows)
+// and the participant's group is not taken if the prize has unique groups.
+// Prizes are sorted by prize no in ascending order.
+func (d *Draw) EligiblePrizes(id string) []Prize {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	id = d.normalizeID(id)
+	p, ok := d.participants[id]
+	if !ok {
+		return []Prize{}
+	}
+
+	m := make(map[int]Prize)
+	for _, no := range d.eligiblePrizeNos(p, d.availablePools()) {
+		m[no] = d.prizes[no]
+	}
+
+	return prizeMapToSlice(m, false)
+}
+
+// availablePools returns IDs of available participants of prizes which are drawable and not full
+// keyed by prize no.
+// The caller should hold the mutex.
+func (d *Draw) availablePools() map[int]map[string]bool {
+	pools := make(map[int]map[string]bool)
+	for no, prize := range d.prizes {
+		amount := d.effectiveAmount(prize)
+		if checkDrawable(amount) != nil || len(d.winners[no]) >= amount {
+			continue
+		}
+
+		pool := make(map[string]bool)
+		for _, p := range d.availableParticipants(no) {
+			pool[p.ID] = true
+		}
+		pools[no] = pool
+	}
+	return pools
+}
+
+// eligiblePrizeNos returns the sorted numbers of prizes the participant can still win
+// with the pools returned by availablePools.
+// The caller should hold the mutex.
+func (d *Draw) eligiblePrizeNos(p Participant, pools map[int]map[string]bool) []int {
+	nos := []int{}
+	for no, pool := range pools {
+		if !pool[p.ID] {
+			continue
+		}
+
+		if d.prizes[no].UniqueGroups && p.Group != "" {
+			taken := false
+			for _, winner := range d.winners[no] {
+				if winner.Group == p.Group {
+					taken = true
+					break
+				}
+			}
+			if taken {
+				continue
+			}
+		}
+
+		nos = append(nos, no)
+	}
+
+	sort.Ints(nos)
+	return nos
+}
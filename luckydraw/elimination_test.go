@@ -0,0 +1,53 @@
+package luckydraw
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+// drawMetrics records the Drawn calls of Metrics.
+type drawMetrics struct {
+	drawn map[int]int
+}
+
+func (m *drawMetrics) Drawn(prizeNo, winners int)                       { m.drawn[prizeNo] += winners }
+func (m *drawMetrics) Redrawn(prizeNo, winners int)                     {}
+func (m *drawMetrics) Revoked(prizeNo, revoked int)                     {}
+func (m *drawMetrics) Updated(participants, prizes, remainingSlots int) {}
+
+func TestEliminationDraw(t *testing.T) {
+	d := newSeededDraw(t, 42)
+
+	m := &drawMetrics{drawn: make(map[int]int)}
+	d.SetMetrics(m)
+	buf := &bytes.Buffer{}
+	d.SetLogger(slog.New(slog.NewTextHandler(buf, nil)))
+
+	sequence, err := d.EliminationDraw(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// All 50 participants are in the sequence with the first ranked winner at the end.
+	if len(sequence) != 50 {
+		t.Errorf("got %d participants in the sequence, want 50", len(sequence))
+	}
+	winners := d.Winners(1)
+	if len(winners) != 3 {
+		t.Fatalf("got %d winners, want 3", len(winners))
+	}
+	for i, w := range winners {
+		if got := sequence[len(sequence)-1-i]; got.ID != w.ID {
+			t.Errorf("sequence[%d]: got %s, want winner %s", len(sequence)-1-i, got.ID, w.ID)
+		}
+	}
+
+	if m.drawn[1] != 3 {
+		t.Errorf("metrics: got %d drawn, want 3", m.drawn[1])
+	}
+	if !strings.Contains(buf.String(), "EliminationDraw") {
+		t.Errorf("log: got %q", buf.String())
+	}
+}
@@ -0,0 +1,75 @@
+package luckydraw
+
+import (
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Entrant is a participant with its status in the draw, see EntrantReport.
+type Entrant struct {
+	Participant
+	// Excluded is true if the participant is excluded from draws, see Exclude.
+	Excluded bool `json:"excluded"`
+	// Won is true if the participant is a winner of any prize.
+	Won bool `json:"won"`
+	// EligiblePrizes are the numbers of prizes the participant can still win in ascending order,
+	// see EligiblePrizes.
+	EligiblePrizes []int `json:"eligible_prizes"`
+}
+
+// EntrantReport returns all participants with their status (excluded, won and the prizes they can still win)
+// sorted by ID, so it answers why someone did or didn't win in one report.
+// It's read in one lock, so the report is consistent.
+func (d *Draw) EntrantReport() []Entrant {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	won := make(map[string]bool)
+	for _, winners := range d.winners {
+		for _, winner := range winners {
+			won[winner.ID] = true
+		}
+	}
+
+	pools := d.availablePools()
+	entrants := []Entrant{}
+	for _, p := range participantMapToSlice(d.participants) {
+		entrants = append(entrants, Entrant{p, d.excluded[p.ID], won[p.ID], d.eligiblePrizeNos(p, pools)})
+	}
+
+	return entrants
+}
+
+// ExportEntrantReportCSV writes the entrant report (see EntrantReport) as CSV.
+// The first row is the header and each row contains: id, name, excluded, won, eligible prizes.
+// Eligible prizes are prize numbers separated by spaces.
+// The format can be configured by SetCSVExportOptions.
+func (d *Draw) ExportEntrantReportCSV(w io.Writer) error {
+	entrants := d.EntrantReport()
+
+	d.mutex.RLock()
+	opts := d.csvOptions
+	d.mutex.RUnlock()
+
+	writer := newCSVWriter(w, opts)
+
+	if err := writer.Write([]string{"id", "name", "excluded", "won", "eligible_prizes"}); err != nil {
+		return err
+	}
+
+	for _, e := range entrants {
+		nos := make([]string, len(e.EligiblePrizes))
+		for i, no := range e.EligiblePrizes {
+			nos[i] = strconv.Itoa(no)
+		}
+
+		row := []string{e.ID, e.Name, strconv.FormatBool(e.Excluded), strconv.FormatBool(e.Won), strings.Join(nos, " ")}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
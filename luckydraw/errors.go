@@ -0,0 +1,159 @@
+package luckydraw
+
+import (
+	"errors"
+)
+
+// ErrorCode identifies an error of the package.
+// It can be used to map errors to messages (e.g. translated messages)
+// without comparing the error text.
+// New codes are always appended, so the values of existing codes never change.
+type ErrorCode int
+
+const (
+	// ErrCodeNone is the code of nil error.
+	ErrCodeNone ErrorCode = iota
+	// ErrCodeUnknown is the code of errors not defined by the package (e.g. I/O errors).
+	ErrCodeUnknown
+	ErrCodeParticipantsCSV
+	ErrCodePrizeNo
+	ErrCodeWinnersExistBeforeDraw
+	ErrCodePrizeAmount
+	ErrCodeNoAvailableParticipants
+	ErrCodeNoOriginalWinnersBeforeRedraw
+	ErrCodeRevokedWinnerNotMatch
+	ErrCodeWinnersNotExistBeforeReDraw
+	ErrCodeRedrawPrizeAmount
+	ErrCodeChecksum
+	ErrCodeDataFileExists
+	ErrCodeParticipantNotFound
+	ErrCodeOpKeyMismatch
+	ErrCodeFinalized
+	ErrCodeParticipantExists
+	ErrCodeHashAlgorithm
+	ErrCodeConstraintUnsatisfiable
+	ErrCodePrizeExists
+	ErrCodeWinnersExist
+	ErrCodeNameMismatch
+	ErrCodeWinnersCSV
+	ErrCodeNoAlternates
+	ErrCodeAllCandidatesRejected
+	ErrCodeUnknownWinner
+	ErrCodeNoGroups
+	ErrCodePrizeNotDrawable
+	ErrCodeNoCommitment
+	ErrCodeNotEnoughParticipants
+	ErrCodeParticipantsLocked
+	ErrCodePartialDraw
+	ErrCodeSaveDataVersion
+	ErrCodePrizeFull
+	ErrCodeNoShowsCSV
+	ErrCodeInconsistentState
+	ErrCodeLimitExceeded
+	ErrCodeDataNotFound
+	ErrCodeNoDataRows
+	ErrCodeCannotFillPrize
+	ErrCodeCSVDelimiter
+	ErrCodeDrawTimeout
+	ErrCodeNoClaimKey
+	ErrCodeNotWinner
+	ErrCodeRegistrationClosed
+	ErrCodeTooManyOutcomes
+	ErrCodeWinnerCapReached
+	ErrCodeNoOperator
+	ErrCodePrizeValue
+	ErrCodeRandAlgorithm
+	ErrCodeSeedNotRevealed
+	ErrCodeSeedKey
+	ErrCodeRandState
+	ErrCodeSeedCommitted
+	ErrCodeBusy
+)
+
+var errorCodes = map[error]ErrorCode{
+	ErrParticipantsCSV:               ErrCodeParticipantsCSV,
+	ErrPrizeNo:                       ErrCodePrizeNo,
+	ErrWinnersExistBeforeDraw:        ErrCodeWinnersExistBeforeDraw,
+	ErrPrizeAmount:                   ErrCodePrizeAmount,
+	ErrNoAvailableParticipants:       ErrCodeNoAvailableParticipants,
+	ErrNoOriginalWinnersBeforeRedraw: ErrCodeNoOriginalWinnersBeforeRedraw,
+	ErrRevokedWinnerNotMatch:         ErrCodeRevokedWinnerNotMatch,
+	ErrWinnersNotExistBeforeReDraw:   ErrCodeWinnersNotExistBeforeReDraw,
+	ErrRedrawPrizeAmount:             ErrCodeRedrawPrizeAmount,
+	ErrChecksum:                      ErrCodeChecksum,
+	ErrDataFileExists:                ErrCodeDataFileExists,
+	ErrParticipantNotFound:           ErrCodeParticipantNotFound,
+	ErrOpKeyMismatch:                 ErrCodeOpKeyMismatch,
+	ErrFinalized:                     ErrCodeFinalized,
+	ErrParticipantExists:             ErrCodeParticipantExists,
+	ErrHashAlgorithm:                 ErrCodeHashAlgorithm,
+	ErrConstraintUnsatisfiable:       ErrCodeConstraintUnsatisfiable,
+	ErrPrizeExists:                   ErrCodePrizeExists,
+	ErrWinnersExist:                  ErrCodeWinnersExist,
+	ErrNameMismatch:                  ErrCodeNameMismatch,
+	ErrWinnersCSV:                    ErrCodeWinnersCSV,
+	ErrNoAlternates:                  ErrCodeNoAlternates,
+	ErrAllCandidatesRejected:         ErrCodeAllCandidatesRejected,
+	ErrUnknownWinner:                 ErrCodeUnknownWinner,
+	ErrNoGroups:                      ErrCodeNoGroups,
+	ErrPrizeNotDrawable:              ErrCodePrizeNotDrawable,
+	ErrNoCommitment:                  ErrCodeNoCommitment,
+	ErrNotEnoughParticipants:         ErrCodeNotEnoughParticipants,
+	ErrParticipantsLocked:            ErrCodeParticipantsLocked,
+	ErrPartialDraw:                   ErrCodePartialDraw,
+	ErrSaveDataVersion:               ErrCodeSaveDataVersion,
+	ErrPrizeFull:                     ErrCodePrizeFull,
+	ErrNoShowsCSV:                    ErrCodeNoShowsCSV,
+	ErrInconsistentState:             ErrCodeInconsistentState,
+	ErrLimitExceeded:                 ErrCodeLimitExceeded,
+	ErrDataNotFound:                  ErrCodeDataNotFound,
+	ErrNoDataRows:                    ErrCodeNoDataRows,
+	ErrCannotFillPrize:               ErrCodeCannotFillPrize,
+	ErrCSVDelimiter:                  ErrCodeCSVDelimiter,
+	ErrDrawTimeout:                   ErrCodeDrawTimeout,
+	ErrNoClaimKey:                    ErrCodeNoClaimKey,
+	ErrNotWinner:                     ErrCodeNotWinner,
+	ErrRegistrationClosed:            ErrCodeRegistrationClosed,
+	ErrTooManyOutcomes:               ErrCodeTooManyOutcomes,
+	ErrWinnerCapReached:              ErrCodeWinnerCapReached,
+	ErrNoOperator:                    ErrCodeNoOperator,
+	ErrPrizeValue:                    ErrCodePrizeValue,
+	ErrRandAlgorithm:                 ErrCodeRandAlgorithm,
+	ErrSeedNotRevealed:               ErrCodeSeedNotRevealed,
+	ErrSeedKey:                       ErrCodeSeedKey,
+	ErrRandState:                     ErrCodeRandState,
+	ErrSeedCommitted:                 ErrCodeSeedCommitted,
+	ErrBusy:                          ErrCodeBusy,
+}
+
+// ErrorCodeOf returns the code of the error.
+// Wrapped errors are supported.
+func ErrorCodeOf(err error) ErrorCode {
+	if err == nil {
+		return ErrCodeNone
+	}
+
+	for e, code := range errorCodes {
+		if errors.Is(err, e) {
+			return code
+		}
+	}
+
+	return ErrCodeUnknown
+}
+
+// ErrorInfo is the JSON shape of an error (e.g. in responses of an HTTP API), see NewErrorInfo and JSONSchema.
+type ErrorInfo struct {
+	// Code is the code of the error, see ErrorCodeOf.
+	Code ErrorCode `json:"code"`
+	// Message is the text of the error.
+	Message string `json:"message"`
+}
+
+// NewErrorInfo returns the info of the error. The info of nil error has ErrCodeNone and an empty message.
+func NewErrorInfo(err error) ErrorInfo {
+	if err == nil {
+		return ErrorInfo{ErrCodeNone, ""}
+	}
+	return ErrorInfo{ErrorCodeOf(err), err.Error()}
+}
@@ -0,0 +1,48 @@
+package luckydraw
+
+// poolExhaustion is a draw which got less winners than wanted because the available participants ran out.
+type poolExhaustion struct {
+	prizeNo int
+	got     int
+	wanted  int
+}
+
+// OnPoolExhausted sets the hook called when a draw (e.g. Draw, DrawAll, Redraw, DrawOne)
+// gets less winners than wanted because the available participants ran out,
+// so the operator can be warned immediately (e.g. to add participants before the next prize).
+// got is 0 if no one is available.
+// f is called after the mutex is released, so it can call methods of the draw. nil removes the hook.
+func (d *Draw) OnPoolExhausted(f func(prizeNo int, got, wanted int)) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.onPoolExhausted = f
+	if f == nil {
+		d.exhaustions = nil
+	}
+}
+
+// notePoolExhausted records the pool exhaustion to be reported by firePoolExhausted.
+// The caller should hold the mutex.
+func (d *Draw) notePoolExhausted(prizeNo, got, wanted int) {
+	if d.onPoolExhausted != nil {
+		d.exhaustions = append(d.exhaustions, poolExhaustion{prizeNo, got, wanted})
+	}
+}
+
+// firePoolExhausted calls the hook set by OnPoolExhausted with the recorded pool exhaustions.
+// It should be called after the mutex is released.
+func (d *Draw) firePoolExhausted() {
+	d.mutex.Lock()
+	f, exhaustions := d.onPoolExhausted, d.exhaustions
+	d.exhaustions = nil
+	d.mutex.Unlock()
+
+	if f == nil {
+		return
+	}
+
+	for _, e := range exhaustions {
+		f(e.prizeNo, e.got, e.wanted)
+	}
+}
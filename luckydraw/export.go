@@ -0,0 +1,222 @@
+package luckydraw
+
+import (
+	cryptorand "crypto/rand"
+	"encoding/binary"
+	"html/template"
+	"io"
+	"math/rand"
+	"sort"
+	"strconv"
+)
+
+// ExportParticipantsCSV writes participants as CSV which can be loaded by LoadParticipantsCSV.
+// The first row is the header and participants are sorted by ID,
+// or shuffled by ShuffleSeed if ShuffleParticipants of the CSV options is set.
+// The columns are id and name. If any participant has a group, entries or extra fields,
+// they're followed by the group, the entries column if any participant has entries,
+// and the extra columns sorted by name.
+// The format can be configured by SetCSVExportOptions.
+func (d *Draw) ExportParticipantsCSV(w io.Writer) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.ensureInit()
+
+	writer := newCSVWriter(w, d.csvOptions)
+
+	participants := participantMapToSlice(d.participants)
+	header, extraKeys, hasEntries := participantsCSVHeader(participants)
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	if d.csvOptions.ShuffleParticipants {
+		// Never use the seed of the draw: the published order would reveal it.
+		seed := d.csvOptions.ShuffleSeed
+		if seed == 0 {
+			buf := make([]byte, 8)
+			if _, err := cryptorand.Read(buf); err != nil {
+				return err
+			}
+			seed = int64(binary.BigEndian.Uint64(buf))
+		}
+		r := rand.New(rand.NewSource(seed))
+		r.Shuffle(len(participants), func(i, j int) {
+			participants[i], participants[j] = participants[j], participants[i]
+		})
+	}
+
+	for _, p := range participants {
+		row := []string{p.ID, p.Name}
+		if len(header) > 2 {
+			row = append(row, p.Group)
+		}
+		if hasEntries {
+			entries := ""
+			if p.Entries != 0 {
+				entries = strconv.Itoa(p.Entries)
+			}
+			row = append(row, entries)
+		}
+		for _, k := range extraKeys {
+			row = append(row, p.Extra[k])
+		}
+
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// participantsCSVHeader returns the header of the participants CSV of ExportParticipantsCSV,
+// the sorted keys of the extra columns and if the entries column is written.
+func participantsCSVHeader(participants []Participant) ([]string, []string, bool) {
+	hasGroup, hasEntries := false, false
+	keys := make(map[string]bool)
+	for _, p := range participants {
+		if p.Group != "" {
+			hasGroup = true
+		}
+		if p.Entries != 0 {
+			hasEntries = true
+		}
+		for k := range p.Extra {
+			keys[k] = true
+		}
+	}
+	if hasEntries {
+		// The entries column is loaded as Participant.Entries.
+		delete(keys, entriesColumn)
+	}
+
+	extraKeys := []string{}
+	for k := range keys {
+		extraKeys = append(extraKeys, k)
+	}
+	sort.Strings(extraKeys)
+
+	header := []string{"id", "name"}
+	if !hasGroup && !hasEntries && len(extraKeys) == 0 {
+		return header, extraKeys, false
+	}
+
+	// The group is the third column even if it's blank, so the following columns are extra columns.
+	header = append(header, "group")
+	if hasEntries {
+		header = append(header, entriesColumn)
+	}
+	return append(header, extraKeys...), extraKeys, hasEntries
+}
+
+// ExportWinnersCSV writes winners as CSV which can be loaded by LoadWinnersCSV.
+// The first row is the header and each row contains: prize no, id, name.
+// Rows are sorted by prize no and winners of each prize are in draw order.
+// The format can be configured by SetCSVExportOptions.
+func (d *Draw) ExportWinnersCSV(w io.Writer) error {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	writer := newCSVWriter(w, d.csvOptions)
+
+	if err := writer.Write([]string{"prize_no", "id", "name"}); err != nil {
+		return err
+	}
+
+	prizeNos := []int{}
+	for prizeNo := range d.winners {
+		prizeNos = append(prizeNos, prizeNo)
+	}
+	sort.Ints(prizeNos)
+
+	for _, prizeNo := range prizeNos {
+		for _, winner := range d.winners[prizeNo] {
+			if err := writer.Write([]string{strconv.Itoa(prizeNo), winner.ID, winner.Name}); err != nil {
+				return err
+			}
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// ExportWinnersGroupedCSV writes winners as CSV grouped by prize for spreadsheets.
+// For each prize sorted by prize no, it writes a header row with the prize no, name and amount,
+// followed by the rows of winners (id, name) in draw order and a blank row between prizes.
+// The format can be configured by SetCSVExportOptions.
+func (d *Draw) ExportWinnersGroupedCSV(w io.Writer) error {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	writer := newCSVWriter(w, d.csvOptions)
+
+	for i, prize := range prizeMapToSlice(d.prizes, false) {
+		if i > 0 {
+			if err := writer.Write(nil); err != nil {
+				return err
+			}
+		}
+
+		if err := writer.Write([]string{strconv.Itoa(prize.No), prize.Name, strconv.Itoa(prize.Amount)}); err != nil {
+			return err
+		}
+
+		for _, winner := range d.winners[prize.No] {
+			if err := writer.Write([]string{winner.ID, winner.Name}); err != nil {
+				return err
+			}
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+var winnersHTMLTemplate = template.Must(template.New("winners").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Name}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+h2 { border-bottom: 1px solid #ccc; }
+td { padding: 0.2em 1em; }
+</style>
+</head>
+<body>
+<h1>{{.Name}}</h1>
+{{range .Prizes}}<h2>{{.Prize.Name}}</h2>
+{{if .Prize.Desc}}<p>{{.Prize.Desc}}</p>
+{{end}}<table>
+{{range .Winners}}<tr><td>{{.ID}}</td><td>{{.Name}}</td></tr>
+{{end}}</table>
+{{end}}</body>
+</html>
+`))
+
+// ExportWinnersHTML writes a standalone HTML page of prizes sorted by prize no and their winners
+// which can be printed or projected.
+// Names are escaped by html/template.
+func (d *Draw) ExportWinnersHTML(w io.Writer) error {
+	type prizeWinners struct {
+		Prize   Prize
+		Winners []Participant
+	}
+
+	d.mutex.RLock()
+	data := struct {
+		Name   string
+		Prizes []prizeWinners
+	}{Name: d.name}
+	for _, prize := range prizeMapToSlice(d.prizes, false) {
+		winners := make([]Participant, len(d.winners[prize.No]))
+		copy(winners, d.winners[prize.No])
+		data.Prizes = append(data.Prizes, prizeWinners{prize, winners})
+	}
+	d.mutex.RUnlock()
+
+	return winnersHTMLTemplate.Execute(w, data)
+}
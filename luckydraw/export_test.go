@@ -0,0 +1,99 @@
+package luckydraw
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExportParticipantsCSVRoundTrip(t *testing.T) {
+	// participants_extra.csv has the group, entries and extra columns.
+	for _, name := range []string{"participants.csv", "participants_extra.csv"} {
+		golden := filepath.Join("testdata", name)
+		want, err := os.ReadFile(golden)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		a := New("export")
+		if err := a.LoadParticipantsCSV(bytes.NewReader(want)); err != nil {
+			t.Fatal(err)
+		}
+
+		buf := &bytes.Buffer{}
+		if err := a.ExportParticipantsCSV(buf); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(buf.Bytes(), want) {
+			t.Errorf("ExportParticipantsCSV differs from %s:\n%s", golden, buf.Bytes())
+		}
+
+		b := New("export")
+		if err := b.LoadParticipantsCSV(buf); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(a.Participants(), b.Participants()) {
+			t.Errorf("%s: participants differ after round-trip: %v, %v", name, a.Participants(), b.Participants())
+		}
+	}
+}
+
+func TestExportParticipantsCSVMetadata(t *testing.T) {
+	a := New("export")
+	for _, p := range []Participant{
+		{ID: "001", Name: "Alice", Group: "sales", Entries: 3, Extra: map[string]string{"email": "alice@example.com", "phone": "1"}},
+		{ID: "002", Name: "Bob", Extra: map[string]string{"phone": "2"}},
+		{ID: "003", Name: "Carol", Group: "dev"},
+	} {
+		if err := a.AddParticipant(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	buf := &bytes.Buffer{}
+	if err := a.ExportParticipantsCSV(buf); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "id,name,group,entries,email,phone\n" +
+		"001,Alice,sales,3,alice@example.com,1\n" +
+		"002,Bob,,,,2\n" +
+		"003,Carol,dev,,,\n"
+	if buf.String() != want {
+		t.Errorf("ExportParticipantsCSV:\n%s\nwant:\n%s", buf.String(), want)
+	}
+
+	b := New("export")
+	if err := b.LoadParticipantsCSV(buf); err != nil {
+		t.Fatal(err)
+	}
+
+	// Blank extra fields are loaded as empty strings.
+	want2 := []Participant{
+		{ID: "001", Name: "Alice", Group: "sales", Entries: 3, Extra: map[string]string{"email": "alice@example.com", "phone": "1"}},
+		{ID: "002", Name: "Bob", Extra: map[string]string{"email": "", "phone": "2"}},
+		{ID: "003", Name: "Carol", Group: "dev", Extra: map[string]string{"email": "", "phone": ""}},
+	}
+	if got := b.Participants(); !reflect.DeepEqual(got, want2) {
+		t.Errorf("participants after round-trip:\n%v\nwant:\n%v", got, want2)
+	}
+}
+
+func TestLoadParticipantsCSVEntries(t *testing.T) {
+	d := New("entries")
+	if err := d.LoadParticipantsCSV(strings.NewReader("id,name,group,entries\n001,a,,2\n002,b,,\n")); err != nil {
+		t.Fatal(err)
+	}
+	got := d.Participants()
+	if got[0].Entries != 2 || got[1].Entries != 0 || got[0].Extra != nil {
+		t.Errorf("got %v", got)
+	}
+
+	if err := d.LoadParticipantsCSV(strings.NewReader("id,name,group,entries\n001,a,,x\n")); !errors.Is(err, ErrParticipantsCSV) {
+		t.Errorf("invalid entries: got %v, want ErrParticipantsCSV", err)
+	}
+}
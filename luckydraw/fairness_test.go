@@ -0,0 +1,52 @@
+package luckydraw
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFairnessReport(t *testing.T) {
+	const (
+		participants = 10
+		amount       = 2
+		iterations   = 10000
+	)
+
+	d := New("fairness")
+	if err := d.SetPrize(1, "prize 1", amount, ""); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < participants; i++ {
+		if err := d.AddParticipant(Participant{ID: fmt.Sprintf("%03d", i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	counts, err := d.FairnessReport(iterations, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(counts) != participants {
+		t.Fatalf("got %d participants, want %d", len(counts), participants)
+	}
+
+	// Chi-square test of uniformity with 9 degrees of freedom.
+	// The critical value at p = 0.0001 is 33.72, so it fails by chance 1 in 10000 runs.
+	expected := float64(iterations*amount) / participants
+	chi2 := 0.0
+	for id, n := range counts {
+		if n == 0 {
+			t.Errorf("%s: never picked", id)
+		}
+		diff := float64(n) - expected
+		chi2 += diff * diff / expected
+	}
+	if chi2 > 33.72 {
+		t.Errorf("distribution is not uniform: chi-square %.2f, counts %v", chi2, counts)
+	}
+
+	// The report doesn't draw.
+	if winners := d.Winners(1); len(winners) != 0 {
+		t.Errorf("got winners %v after FairnessReport", winners)
+	}
+}
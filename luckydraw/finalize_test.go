@@ -0,0 +1,81 @@
+package luckydraw
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestFinalize(t *testing.T) {
+	d := newSeededDraw(t, 42)
+	if _, err := d.Draw(1); err != nil {
+		t.Fatal(err)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := d.Save(buf); err != nil {
+		t.Fatal(err)
+	}
+	saved := buf.Bytes()
+
+	d.Finalize()
+	if !d.IsFinalized() {
+		t.Fatal("IsFinalized: got false")
+	}
+	winners := d.Winners(1)
+
+	for name, f := range map[string]func() error{
+		"Draw":         func() error { _, err := d.Draw(2); return err },
+		"Revoke":       func() error { _, err := d.Revoke(1, winners[:1]); return err },
+		"Redraw":       func() error { _, err := d.Redraw(1, 1); return err },
+		"ClearWinners": func() error { return d.ClearWinners(1) },
+		"SetPrize":     func() error { return d.SetPrize(4, "prize 4", 1, "") },
+		"AddParticipant": func() error {
+			return d.AddParticipant(Participant{ID: "new"})
+		},
+		"SetMultiWin":      func() error { return d.SetMultiWin(true) },
+		"SetWeightFunc":    func() error { return d.SetWeightFunc(nil) },
+		"SetHashAlgorithm": func() error { return d.SetHashAlgorithm(HashSHA256) },
+		"Load":             func() error { return d.Load(bytes.NewReader(saved)) },
+	} {
+		if err := f(); err != ErrFinalized {
+			t.Errorf("%s: got %v, want ErrFinalized", name, err)
+		}
+	}
+
+	// Read methods keep working and nothing is changed.
+	if got := d.Winners(1); len(got) != 3 {
+		t.Errorf("Winners: got %v", got)
+	}
+	if got := d.Winners(2); len(got) != 0 {
+		t.Errorf("Winners(2): got %v", got)
+	}
+}
+
+func TestFinalizedSaved(t *testing.T) {
+	d := newSeededDraw(t, 42)
+	d.Finalize()
+
+	buf := &bytes.Buffer{}
+	if err := d.Save(buf); err != nil {
+		t.Fatal(err)
+	}
+
+	b := New("seed")
+	if err := b.Load(bytes.NewReader(buf.Bytes())); err != nil {
+		t.Fatal(err)
+	}
+	if !b.IsFinalized() {
+		t.Errorf("IsFinalized after load: got false")
+	}
+
+	// The flag is covered by the checksum.
+	tampered := strings.Replace(buf.String(), `"finalized": true`, `"finalized": false`, 1)
+	if tampered == buf.String() {
+		t.Fatal("finalized not found in save data")
+	}
+	if err := New("seed").Load(strings.NewReader(tampered)); !errors.Is(err, ErrChecksum) {
+		t.Errorf("got %v, want ErrChecksum", err)
+	}
+}
@@ -0,0 +1,192 @@
+// Package flow provides a UI-agnostic state machine of the stage flow of a lucky draw:
+// select a prize, confirm, commit the draw and reveal the winners.
+// Frontends (e.g. TUI, web) share the flow and only render the states.
+// It does no I/O itself: frontends save the draw (e.g. SaveToFile) when they need to.
+package flow
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/northbright/luckydraw-go/luckydraw"
+)
+
+// State is a state of the flow.
+type State int
+
+const (
+	// Idle means no prize is selected.
+	Idle State = iota
+	// PrizeSelected means a prize is selected and waits for confirmation.
+	PrizeSelected
+	// Confirmed means the selected prize is confirmed and ready to draw.
+	Confirmed
+	// Drawn means the winners are drawn but not revealed yet (e.g. during a drum roll).
+	Drawn
+	// Revealed means the winners are revealed.
+	Revealed
+)
+
+func (s State) String() string {
+	switch s {
+	case Idle:
+		return "idle"
+	case PrizeSelected:
+		return "prize selected"
+	case Confirmed:
+		return "confirmed"
+	case Drawn:
+		return "drawn"
+	case Revealed:
+		return "revealed"
+	default:
+		return fmt.Sprintf("State(%d)", int(s))
+	}
+}
+
+var (
+	ErrInvalidTransition = fmt.Errorf("invalid transition")
+)
+
+// Machine is the state machine of the flow of a draw. Its methods are safe for concurrent use.
+// A transition which is not allowed in the current state returns ErrInvalidTransition,
+// and a transition whose draw method fails returns its error. The state is not changed in both cases.
+type Machine struct {
+	mutex   sync.Mutex
+	d       *luckydraw.Draw
+	state   State
+	prizeNo int
+	winners []luckydraw.Participant
+}
+
+// New creates a machine of the draw in Idle state.
+func New(d *luckydraw.Draw) *Machine {
+	return &Machine{d: d}
+}
+
+// State returns the current state.
+func (m *Machine) State() State {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return m.state
+}
+
+// PrizeNo returns the selected prize no. It's 0 in Idle state.
+func (m *Machine) PrizeNo() int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return m.prizeNo
+}
+
+// SelectPrize selects the prize. It's allowed in Idle, PrizeSelected and Revealed states
+// and moves to PrizeSelected.
+// It returns luckydraw.ErrPrizeNo if the prize doesn't exist
+// and luckydraw.ErrWinnersExistBeforeDraw if the prize is drawn.
+func (m *Machine) SelectPrize(prizeNo int) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.state != Idle && m.state != PrizeSelected && m.state != Revealed {
+		return ErrInvalidTransition
+	}
+
+	if _, ok := m.d.LookupPrize(prizeNo); !ok {
+		return luckydraw.ErrPrizeNo
+	}
+
+	if len(m.d.Winners(prizeNo)) > 0 {
+		return luckydraw.ErrWinnersExistBeforeDraw
+	}
+
+	m.prizeNo = prizeNo
+	m.winners = nil
+	m.state = PrizeSelected
+	return nil
+}
+
+// Confirm confirms the selected prize. It moves from PrizeSelected to Confirmed.
+func (m *Machine) Confirm() error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.state != PrizeSelected {
+		return ErrInvalidTransition
+	}
+
+	m.state = Confirmed
+	return nil
+}
+
+// Commit draws the selected prize by Draw. It moves from Confirmed to Drawn.
+// The winners are recorded by the draw, but they're returned by Reveal only.
+// A prize whose winners were cleared by Undo is drawn again by RedrawPrize.
+func (m *Machine) Commit() error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.state != Confirmed {
+		return ErrInvalidTransition
+	}
+
+	winners, err := m.d.Draw(m.prizeNo)
+	if errors.Is(err, luckydraw.ErrWinnersExistBeforeDraw) && len(m.d.Winners(m.prizeNo)) == 0 {
+		// ClearWinners keeps the prize drawn with no winners.
+		winners, err = m.d.RedrawPrize(m.prizeNo)
+	}
+	if err != nil {
+		return err
+	}
+
+	m.winners = winners
+	m.state = Drawn
+	return nil
+}
+
+// Reveal returns the winners drawn by Commit. It moves from Drawn to Revealed.
+func (m *Machine) Reveal() ([]luckydraw.Participant, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.state != Drawn {
+		return nil, ErrInvalidTransition
+	}
+
+	m.state = Revealed
+	return append([]luckydraw.Participant{}, m.winners...), nil
+}
+
+// Undo goes back one step:
+// from PrizeSelected to Idle, from Confirmed to PrizeSelected,
+// and from Drawn or Revealed to PrizeSelected after clearing the winners of the prize by ClearWinners.
+// The random source is rewound by RewindRNG after clearing the winners,
+// so every draw of the prize after an undo gives the same winners with the same participants
+// and undo can't be repeated to re-roll them.
+// It's not allowed in Idle state.
+func (m *Machine) Undo() error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	switch m.state {
+	case PrizeSelected:
+		m.prizeNo = 0
+		m.state = Idle
+	case Confirmed:
+		m.state = PrizeSelected
+	case Drawn, Revealed:
+		if err := m.d.ClearWinners(m.prizeNo); err != nil {
+			return err
+		}
+		if err := m.d.RewindRNG(); err != nil {
+			return err
+		}
+		m.winners = nil
+		m.state = PrizeSelected
+	default:
+		return ErrInvalidTransition
+	}
+
+	return nil
+}
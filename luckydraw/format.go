@@ -0,0 +1,88 @@
+package luckydraw
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+)
+
+// gzipMagic is the magic number at the beginning of gzip data.
+const gzipMagic = "\x1f\x8b"
+
+// decodeSaveData decodes the save data from r.
+// The format is detected by the beginning of the data:
+// gzip data is decompressed, otherwise it's decoded as plain JSON.
+func decodeSaveData(r io.Reader, data *SaveData) error {
+	br := bufio.NewReader(r)
+
+	if b, err := br.Peek(len(gzipMagic)); err == nil && string(b) == gzipMagic {
+		zr, err := gzip.NewReader(br)
+		if err != nil {
+			return err
+		}
+		defer zr.Close()
+
+		return json.NewDecoder(zr).Decode(data)
+	}
+
+	return json.NewDecoder(br).Decode(data)
+}
+
+// saveFormat is the format of encoded save data.
+type saveFormat struct {
+	gzipped  bool
+	indented bool
+}
+
+// detectSaveFormat detects the format of the encoded save data in buf,
+// so the data can be written back in the same format.
+func detectSaveFormat(buf []byte) (saveFormat, error) {
+	format := saveFormat{}
+
+	if bytes.HasPrefix(buf, []byte(gzipMagic)) {
+		zr, err := gzip.NewReader(bytes.NewReader(buf))
+		if err != nil {
+			return format, err
+		}
+		defer zr.Close()
+
+		if buf, err = io.ReadAll(zr); err != nil {
+			return format, err
+		}
+		format.gzipped = true
+	}
+
+	// Save writes "{" followed by a newline, SaveCompact doesn't.
+	format.indented = bytes.HasPrefix(bytes.TrimLeft(buf, " \t\r\n"), []byte("{\n"))
+	return format, nil
+}
+
+// encodeSaveData encodes the save data in the format.
+func encodeSaveData(data *SaveData, format saveFormat) ([]byte, error) {
+	out := &bytes.Buffer{}
+
+	var w io.Writer = out
+	var zw *gzip.Writer
+	if format.gzipped {
+		zw = gzip.NewWriter(out)
+		w = zw
+	}
+
+	enc := json.NewEncoder(w)
+	if format.indented {
+		enc.SetIndent("", "    ")
+	}
+	if err := enc.Encode(data); err != nil {
+		return nil, err
+	}
+
+	if zw != nil {
+		if err := zw.Close(); err != nil {
+			return nil, err
+		}
+	}
+
+	return out.Bytes(), nil
+}
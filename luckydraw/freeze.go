@@ -0,0 +1,78 @@
+package luckydraw
+
+import (
+	"sort"
+)
+
+// FreezePool freezes the pool of the prize to its current available participants and returns them
+// (e.g. when "drawing prize 5 now" is announced while registrations are still coming in).
+// Following draws of the prize only pick participants in the frozen pool,
+// so participants added later can't win the prize.
+// Participants in the frozen pool who become unavailable (e.g. win another prize or get excluded) can't win either.
+// Freezing again replaces the frozen pool. The frozen pool is saved with the draw.
+func (d *Draw) FreezePool(prizeNo int) (pool []Participant, err error) {
+	defer func() { d.mutated(err) }()
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.ensureInit()
+
+	if d.finalized {
+		return []Participant{}, ErrFinalized
+	}
+
+	if _, ok := d.prizes[prizeNo]; !ok {
+		return []Participant{}, ErrPrizeNo
+	}
+
+	pool = d.availableParticipants(prizeNo)
+
+	ids := []string{}
+	for _, p := range pool {
+		ids = append(ids, p.ID)
+	}
+	d.frozenPools[prizeNo] = ids
+
+	return pool, nil
+}
+
+// UnfreezePool removes the frozen pool of the prize, see FreezePool.
+func (d *Draw) UnfreezePool(prizeNo int) (err error) {
+	defer func() { d.mutated(err) }()
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.ensureInit()
+
+	if d.finalized {
+		return ErrFinalized
+	}
+
+	delete(d.frozenPools, prizeNo)
+	return nil
+}
+
+// FrozenPool returns the participants in the frozen pool of the prize sorted by ID to be published.
+// It returns false if the pool of the prize is not frozen.
+// Participants removed after the pool is frozen are not returned.
+func (d *Draw) FrozenPool(prizeNo int) ([]Participant, bool) {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	ids, ok := d.frozenPools[prizeNo]
+	if !ok {
+		return []Participant{}, false
+	}
+
+	pool := []Participant{}
+	for _, id := range ids {
+		if p, ok := d.participants[id]; ok {
+			pool = append(pool, p)
+		}
+	}
+	sort.Slice(pool, func(i, j int) bool { return pool[i].ID < pool[j].ID })
+
+	return pool, true
+}
@@ -0,0 +1,132 @@
+package luckydraw
+
+import (
+	"math/rand"
+	"time"
+)
+
+// DrawOne draws one more winner of the prize and appends it to the winners of the prize.
+// Unlike Draw, it can be called repeatedly until the prize is full.
+// It returns ErrPrizeFull if the prize already has the full amount of winners
+// and ErrNoAvailableParticipants if no one can win.
+func (d *Draw) DrawOne(prizeNo int) (winner Participant, err error) {
+	var winners []Participant
+
+	defer func() { d.mutated(err) }()
+	defer func() { d.observeDraw(prizeNo, winners, err) }()
+	defer func(start time.Time) {
+		d.logOp("DrawOne", start, err, "prize_no", prizeNo, "winners", len(winners))
+	}(time.Now())
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	// Keep the pool cache, drawOne removes the winner from it.
+	d.initState()
+
+	if d.finalized {
+		return Participant{}, ErrFinalized
+	}
+
+	winners, err = d.drawOne(prizeNo)
+	if err != nil {
+		return Participant{}, err
+	}
+	return winners[0], nil
+}
+
+// drawOne draws one more winner of the prize and appends it to the winners of the prize.
+// The caller should hold the mutex.
+func (d *Draw) drawOne(prizeNo int) ([]Participant, error) {
+	if _, ok := d.prizes[prizeNo]; !ok {
+		return []Participant{}, ErrPrizeNo
+	}
+
+	prize := d.resolvePercentAmount(prizeNo)
+
+	if err := checkDrawable(prize.Amount); err != nil {
+		return []Participant{}, err
+	}
+
+	if len(d.winners[prizeNo]) >= prize.Amount {
+		return []Participant{}, ErrPrizeFull
+	}
+
+	participants := d.cachedAvailableParticipants(prizeNo)
+	if len(participants) == 0 {
+		d.notePoolExhausted(prizeNo, 0, 1)
+		return []Participant{}, ErrNoAvailableParticipants
+	}
+
+	winners, err := d.selectWinners(d.rnd, prizeNo, 1, participants)
+	if err != nil {
+		return []Participant{}, err
+	}
+
+	if len(winners) == 0 {
+		d.notePoolExhausted(prizeNo, 0, 1)
+		return []Participant{}, ErrNoAvailableParticipants
+	}
+
+	d.prizes[prizeNo] = prize
+	d.winners[prizeNo] = append(d.winners[prizeNo], winners[0])
+	d.indexWinners(prizeNo)
+	d.recordSequence(prizeNo, winners)
+	d.removeFromPoolCache(prizeNo, winners[0])
+	return winners, nil
+}
+
+// DrawUntilExhausted calls DrawOne repeatedly until the prize is full or no one remains
+// (e.g. a giveaway show where winners come out one by one until the pool runs dry).
+// emit is called with each winner as it's drawn, without holding the mutex.
+// It returns all winners drawn by the call.
+// Running out of participants, or of candidates which satisfy the constraints of the prize, is not an error.
+func (d *Draw) DrawUntilExhausted(prizeNo int, emit func(Participant)) ([]Participant, error) {
+	winners := []Participant{}
+
+	for {
+		winner, err := d.DrawOne(prizeNo)
+		switch err {
+		case nil:
+		case ErrPrizeFull, ErrNoAvailableParticipants, ErrNotEnoughParticipants, ErrConstraintUnsatisfiable, ErrAllCandidatesRejected:
+			return winners, nil
+		default:
+			return winners, err
+		}
+
+		winners = append(winners, winner)
+		if emit != nil {
+			emit(winner)
+		}
+	}
+}
+
+// SampleOne returns a random available participant of the prize without recording anything
+// (e.g. the flashing candidates of a spinning wheel before DrawOne commits the real winner).
+// It uses its own random source, so the random sequence of the draw and the winners are not affected.
+// It returns ErrNoAvailableParticipants if no one can win.
+func (d *Draw) SampleOne(prizeNo int) (Participant, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if _, ok := d.prizes[prizeNo]; !ok {
+		return Participant{}, ErrPrizeNo
+	}
+
+	// Use the pool cache of DrawOne if it's up to date, see SetPoolCache.
+	var participants []Participant
+	if c := d.poolCache; c != nil && c.prizeNo == prizeNo {
+		participants = c.participants
+	} else {
+		participants = d.availableParticipants(prizeNo)
+	}
+
+	if len(participants) == 0 {
+		return Participant{}, ErrNoAvailableParticipants
+	}
+
+	if d.sampleRnd == nil {
+		d.sampleRnd = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	return participants[d.sampleRnd.Intn(len(participants))], nil
+}
@@ -0,0 +1,112 @@
+package luckydraw
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// LoadWinnersCSV loads winners drawn by an external system from CSV.
+// The first row is the header and each row contains: prize no, id, name.
+// The name column is for readability only, winners are taken from the participants.
+// It validates that prizes and participants exist, a prize doesn't have more winners than its amount
+// (the resolved amount for percent prizes, see SetPrizePercent)
+// and a participant doesn't win more than once unless multi-win is on.
+// Winners are loaded in row order and replace all existing winners,
+// and the draw sequences, the revoke history and the alternates of the previous winners are discarded.
+// If it returns an error, existing winners are kept.
+func (d *Draw) LoadWinnersCSV(r io.Reader) (err error) {
+	defer func() { d.mutated(err) }()
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.ensureInit()
+
+	if d.finalized {
+		return ErrFinalized
+	}
+
+	reader := csv.NewReader(r)
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return err
+	}
+
+	winners := make(map[int][]Participant)
+	prizes := make(map[int]Prize)
+	wins := make(map[string]int)
+	for i := 1; i < len(rows); i++ {
+		row := rows[i]
+		if len(row) != 3 {
+			return fmt.Errorf("row %d: %w", i+1, ErrWinnersCSV)
+		}
+
+		no, err := strconv.Atoi(strings.Trim(row[0], " "))
+		if err != nil {
+			return fmt.Errorf("row %d: %w", i+1, err)
+		}
+
+		if _, ok := d.prizes[no]; !ok {
+			return fmt.Errorf("row %d: prize no %d: %w", i+1, no, ErrPrizeNo)
+		}
+
+		prize, ok := prizes[no]
+		if !ok {
+			prize = d.resolvePercentAmount(no)
+			prizes[no] = prize
+		}
+
+		id := d.normalizeID(strings.Trim(row[1], " "))
+		p, ok := d.participants[id]
+		if !ok {
+			return fmt.Errorf("row %d: id %s: %w", i+1, id, ErrParticipantNotFound)
+		}
+
+		if containsParticipant(winners[no], id) {
+			return fmt.Errorf("row %d: id %s: %w", i+1, id, ErrWinnersCSV)
+		}
+
+		if len(winners[no]) >= prize.Amount {
+			return fmt.Errorf("row %d: prize no %d: %w", i+1, no, ErrPrizeAmount)
+		}
+
+		wins[id]++
+		if (!d.multiWin && wins[id] > 1) ||
+			(d.multiWin && d.maxWinsPerParticipant > 0 && wins[id] > d.maxWinsPerParticipant) {
+			return fmt.Errorf("row %d: id %s: %w", i+1, id, ErrWinnersCSV)
+		}
+
+		winners[no] = append(winners[no], p)
+	}
+
+	for no, prize := range prizes {
+		d.prizes[no] = prize
+	}
+
+	d.winners = winners
+	d.reindexWinners()
+
+	// The state derived from the previous winners doesn't match the loaded winners.
+	d.sequences = make(map[int][]string)
+	for no, s := range winners {
+		d.recordSequence(no, s)
+	}
+	d.revoked = make(map[int][]RevokedWinner)
+	d.alternates = make(map[int][]Participant)
+	d.drawOps = nil
+	return nil
+}
+
+func (d *Draw) LoadWinnersCSVFile(file string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return d.LoadWinnersCSV(f)
+}
@@ -0,0 +1,102 @@
+package luckydraw
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadWinnersCSV(t *testing.T) {
+	d := newSeededDraw(t, 42)
+	if _, _, err := d.DrawWithAlternates(1, 2); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.Revoke(1, d.Winners(1)[:1]); err != nil {
+		t.Fatal(err)
+	}
+
+	csv := "prize_no,id,name\n1,010,name 10\n1,001,name 1\n2,002,name 2\n"
+	if err := d.LoadWinnersCSV(strings.NewReader(csv)); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := d.Winners(1); len(got) != 2 || got[0].ID != "010" || got[1].ID != "001" {
+		t.Errorf("Winners(1): got %v", got)
+	}
+	if got := d.DrawSequence(1); !reflect.DeepEqual(got, []string{"010", "001"}) {
+		t.Errorf("DrawSequence(1): got %v", got)
+	}
+	if got := d.Revoked(1); len(got) != 0 {
+		t.Errorf("Revoked(1): got %v", got)
+	}
+	if got := d.Alternates(1); len(got) != 0 {
+		t.Errorf("Alternates(1): got %v", got)
+	}
+	loaded := map[string]bool{"010": true, "001": true, "002": true}
+	for _, p := range d.Participants() {
+		if d.IsWinner(p.ID) != loaded[p.ID] {
+			t.Errorf("IsWinner(%s): got %v", p.ID, !loaded[p.ID])
+		}
+	}
+
+	// The loaded winners can be saved and loaded.
+	buf := &bytes.Buffer{}
+	if err := d.Save(buf); err != nil {
+		t.Fatal(err)
+	}
+	if err := New("seed").Load(buf); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadWinnersCSVErrors(t *testing.T) {
+	d := newSeededDraw(t, 42)
+	if _, err := d.Draw(1); err != nil {
+		t.Fatal(err)
+	}
+	want := d.AllWinners()
+
+	for _, c := range []struct {
+		name string
+		csv  string
+		err  error
+	}{
+		{"unknown prize", "prize_no,id,name\n9,001,\n", ErrPrizeNo},
+		{"unknown participant", "prize_no,id,name\n1,x,\n", ErrParticipantNotFound},
+		{"duplicate winner", "prize_no,id,name\n1,001,\n1,001,\n", ErrWinnersCSV},
+		{"multi-win", "prize_no,id,name\n1,001,\n2,001,\n", ErrWinnersCSV},
+		{"over amount", "prize_no,id,name\n1,001,\n1,002,\n1,003,\n1,004,\n", ErrPrizeAmount},
+		{"bad row", "prize_no,id\n1,001\n", ErrWinnersCSV},
+	} {
+		if err := d.LoadWinnersCSV(strings.NewReader(c.csv)); !errors.Is(err, c.err) {
+			t.Errorf("%s: got %v, want %v", c.name, err, c.err)
+		}
+		if got := d.AllWinners(); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: winners changed after failed load", c.name)
+		}
+	}
+}
+
+func TestLoadWinnersCSVPercentPrize(t *testing.T) {
+	d := newSeededDraw(t, 42)
+	// 10% of 50 participants is 5.
+	if err := d.SetPrizePercent(1, 10); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := d.LoadWinnersCSV(strings.NewReader("prize_no,id,name\n1,001,\n1,002,\n1,003,\n1,004,\n1,005,\n")); err != nil {
+		t.Fatal(err)
+	}
+	if prize, _ := d.LookupPrize(1); prize.Amount != 5 {
+		t.Errorf("got amount %d, want 5", prize.Amount)
+	}
+	if err := d.Save(&discard{}); err != nil {
+		t.Errorf("Save: %v", err)
+	}
+
+	if err := d.LoadWinnersCSV(strings.NewReader("prize_no,id,name\n1,001,\n1,002,\n1,003,\n1,004,\n1,005,\n1,006,\n")); !errors.Is(err, ErrPrizeAmount) {
+		t.Errorf("over amount: got %v, want ErrPrizeAmount", err)
+	}
+}
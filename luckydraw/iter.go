@@ -0,0 +1,28 @@
+package luckydraw
+
+import (
+	"iter"
+)
+
+// ForEachParticipant calls f for each participant without copying participants to a slice.
+// Participants are visited in no particular order. It stops if f returns false.
+// f is called with the mutex held, so it must not call methods of the draw.
+func (d *Draw) ForEachParticipant(f func(Participant) bool) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	for _, p := range d.participants {
+		if !f(p) {
+			return
+		}
+	}
+}
+
+// ParticipantsSeq returns an iterator over participants like ForEachParticipant
+// which can be used with range, e.g. for p := range d.ParticipantsSeq() { ... }.
+// The loop body runs with the mutex held, so it must not call methods of the draw.
+func (d *Draw) ParticipantsSeq() iter.Seq[Participant] {
+	return func(yield func(Participant) bool) {
+		d.ForEachParticipant(yield)
+	}
+}
@@ -0,0 +1,82 @@
+package luckydraw
+
+import (
+	"fmt"
+)
+
+// SetMaxParticipants sets the max number of participants (e.g. to limit untrusted uploads).
+// The participants loaders, SetParticipants, AddParticipant, Load, ApplySaveData and Merge
+// return ErrLimitExceeded if the limit is exceeded.
+// CSV loaders stop reading as soon as the limit is exceeded.
+// 0 (default) means no limit. It doesn't affect existing participants.
+func (d *Draw) SetMaxParticipants(n int) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.maxParticipants = n
+}
+
+// SetMaxPrizes sets the max number of prizes.
+// LoadPrizesCSV, SetPrize, SetPrizes, Load, ApplySaveData and Merge return ErrLimitExceeded if the limit is exceeded.
+// 0 (default) means no limit. It doesn't affect existing prizes.
+func (d *Draw) SetMaxPrizes(n int) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.maxPrizes = n
+}
+
+// Reserve pre-sizes the participants of the draw for n participants (e.g. millions of entrants),
+// so loading participants doesn't grow the map repeatedly.
+// The participants loaders pre-size the loaded participants for n too.
+// It's a hint only and doesn't limit the number of participants, see SetMaxParticipants.
+func (d *Draw) Reserve(n int) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.ensureInit()
+
+	d.expectedParticipants = n
+	if n > len(d.participants) {
+		participants := make(map[string]Participant, n)
+		for id, p := range d.participants {
+			participants[id] = p
+		}
+		d.participants = participants
+	}
+}
+
+// checkParticipantsLimit checks if n participants are allowed.
+// The caller should hold the mutex.
+func (d *Draw) checkParticipantsLimit(n int) error {
+	if d.maxParticipants > 0 && n > d.maxParticipants {
+		return fmt.Errorf("max participants %d: %w", d.maxParticipants, ErrLimitExceeded)
+	}
+	return nil
+}
+
+// checkPrizesLimit checks if n prizes are allowed.
+// The caller should hold the mutex.
+func (d *Draw) checkPrizesLimit(n int) error {
+	if d.maxPrizes > 0 && n > d.maxPrizes {
+		return fmt.Errorf("max prizes %d: %w", d.maxPrizes, ErrLimitExceeded)
+	}
+	return nil
+}
+
+// checkSaveDataLimits checks the prize amounts and the numbers of prizes and participants of the data
+// against the limits of the draw (see SetMaxPrizeAmount, SetMaxPrizes and SetMaxParticipants).
+// The caller should hold the mutex.
+func (d *Draw) checkSaveDataLimits(data *SaveData) error {
+	for no, prize := range data.Prizes {
+		if err := d.checkPrizeAmount(prize.Amount); err != nil {
+			return fmt.Errorf("prize no %d: %w", no, err)
+		}
+	}
+
+	if err := d.checkPrizesLimit(len(data.Prizes)); err != nil {
+		return err
+	}
+
+	return d.checkParticipantsLimit(len(data.Participants))
+}
@@ -0,0 +1,37 @@
+package luckydraw
+
+import (
+	"log/slog"
+	"time"
+)
+
+// SetLogger sets the structured logger of the draw.
+// Draws, redraws, revokes, loads and saves are logged with the prize no, counts, duration and error.
+// Successful operations are logged at info level and failed ones at warn level.
+// Logs are written after the mutex is released.
+// nil (default) means no logs.
+func (d *Draw) SetLogger(logger *slog.Logger) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.logger = logger
+}
+
+// logOp logs the operation started at start with its error and attributes.
+// It should be called after the mutex is released.
+func (d *Draw) logOp(op string, start time.Time, err error, args ...any) {
+	d.mutex.Lock()
+	logger := d.logger
+	d.mutex.Unlock()
+
+	if logger == nil {
+		return
+	}
+
+	args = append(args, "duration", time.Since(start))
+	if err != nil {
+		logger.Warn(op, append(args, "error", err)...)
+		return
+	}
+	logger.Info(op, args...)
+}
@@ -1,14 +1,20 @@
 package luckydraw
 
 import (
+	"bufio"
+	"bytes"
 	"crypto/md5"
+	"crypto/sha256"
 	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"hash"
 	"io"
+	"log/slog"
 	"math/rand"
 	"os"
 	"path"
+	"path/filepath"
 	"sort"
 	"strconv"
 	"strings"
@@ -17,8 +23,22 @@ import (
 )
 
 type Participant struct {
-	ID   string `json:"id"`
+	ID string `json:"id"`
+	// Name is optional (e.g. only IDs are published for privacy).
+	// A blank name is loaded, saved, hashed and exported as an empty string.
 	Name string `json:"name"`
+	// Group is the optional group (e.g. department) of the participant.
+	Group string `json:"group,omitempty"`
+	// Extra contains additional columns of the participants CSV (e.g. email, phone).
+	// Keys are the column names in the CSV header.
+	Extra map[string]string `json:"extra,omitempty"`
+	// Entries is the number of entries of the participant (e.g. extra entries for referrals).
+	// A participant with 3 entries is 3 times as likely to be picked, but still wins at most once.
+	// Values less than 1 mean 1 entry. It's not applied in raffle mode, see AddTickets.
+	Entries int `json:"entries,omitempty"`
+	// Inactive marks a soft-deleted participant who is kept for audit but can't win any more.
+	// See Deactivate.
+	Inactive bool `json:"inactive,omitempty"`
 }
 
 type Prize struct {
@@ -26,23 +46,265 @@ type Prize struct {
 	Name   string `json:"name"`
 	Amount int    `json:"amount"`
 	Desc   string `json:"desc"`
+	// Extra contains additional columns of the prizes CSV (e.g. image URL, sponsor).
+	// Keys are the column names in the CSV header.
+	Extra map[string]string `json:"extra,omitempty"`
+	// UniqueGroups makes the draw pick at most one winner from each group for the prize.
+	// Participants without a group are not restricted.
+	UniqueGroups bool `json:"unique_groups,omitempty"`
+	// Tiers are the optional sub-tiers of the prize (e.g. gold, silver, bronze) in award order.
+	// See SetPrizeTiers.
+	Tiers []Tier `json:"tiers,omitempty"`
+	// Percent makes the amount a percentage of the participants resolved at draw time.
+	// See SetPrizePercent.
+	Percent float64 `json:"percent,omitempty"`
+	// Consolation makes the prize drawn from participants who haven't won any prize,
+	// even if multi-win or raffle mode is on. See SetPrizeConsolation.
+	Consolation bool `json:"consolation,omitempty"`
+	// Value is the optional value of each winner of the prize (e.g. in cents).
+	// See DrawWithinBudget.
+	Value int `json:"value,omitempty"`
 }
 
+// Draw is a lucky draw of prizes among participants. Its methods are safe for concurrent use.
+// Read methods (e.g. Winners, Prizes, Stats) share a read lock, so they don't block each other,
+// and methods which change the draw lock it exclusively.
+// A Draw must not be copied after first use: pass *Draw instead.
+// It contains a sync.RWMutex by value, so copies are reported by the copylocks check of go vet.
 type Draw struct {
 	name         string
 	prizes       map[int]Prize
 	participants map[string]Participant
 	winners      map[int][]Participant
-	mutex        *sync.Mutex
+	src          *randSource
+	rnd          *rand.Rand
+	mutex        sync.RWMutex
+	// fileMutex serializes writes of the data file. It's locked before mutex.
+	fileMutex sync.Mutex
+	// drawingMutex guards drawing. It's locked without holding mutex.
+	drawingMutex sync.Mutex
+	// drawing counts the draws in progress keyed by prize no, see DrawInProgress.
+	drawing map[int]int
+	// multiWin allows a participant to win more than one prize.
+	multiWin bool
+	// maxWinsPerParticipant caps the number of prizes a participant can win when multiWin is on.
+	// 0 means no cap.
+	maxWinsPerParticipant int
+	// finalized is set by Finalize. No mutation is allowed after that.
+	finalized bool
+	// autoSave makes the draw save to the data file after each mutation.
+	autoSave bool
+	// onAutoSaveError is called when auto save fails.
+	onAutoSaveError func(error)
+	// hashAlgorithm is the hash algorithm of the checksum.
+	hashAlgorithm string
+	// revoked is the revoke history of winners keyed by prize no.
+	revoked map[int][]RevokedWinner
+	// ignoreNameMismatch makes Load accept data with another name.
+	ignoreNameMismatch bool
+	// acceptLegacyChecksum makes Load accept data with the checksum of older versions.
+	acceptLegacyChecksum bool
+	// audit is the audit log of operations done by DrawBy and RevokeBy.
+	audit []AuditEntry
+	// drawOps records the draws done by DrawIdempotent keyed by operation keys.
+	drawOps map[string]drawOp
+	// excluded contains IDs of participants who are excluded from draws (e.g. winners of previous rounds).
+	excluded map[string]bool
+	// winnerIndex contains IDs of winners keyed by prize no and winCounts counts the prizes won by each ID.
+	// They're kept in sync with winners by indexWinners, so IsWinner and IsWinnerOf are O(1).
+	winnerIndex map[int]map[string]bool
+	winCounts   map[string]int
+	// maxPrizeAmount is the max amount of a prize. 0 means no limit.
+	maxPrizeAmount int
+	// alternates contains ranked backup winners keyed by prize no.
+	alternates map[int][]Participant
+	// weightFunc computes weights of participants for weighted draws. nil means no weights.
+	weightFunc WeightFunc
+	// penaltyFunc lowers weights of recent winners. nil means no penalty.
+	penaltyFunc PenaltyFunc
+	// clock returns the current time for timestamps. nil means time.Now.
+	clock func() time.Time
+	// commitment is the commitment of the seed generated by CommitSeed.
+	commitment string
+	// metrics receives operation metrics. nil means no metrics.
+	metrics Metrics
+	// keepParticipantSpaces makes the participants CSV loaders keep surrounding whitespace of IDs and names.
+	keepParticipantSpaces bool
+	// unlockParticipants allows changing participants after drawing.
+	unlockParticipants bool
+	// raffle makes participants hold tickets which are drawn with replacement.
+	raffle bool
+	// tickets is the number of tickets of participants in raffle mode keyed by ID.
+	tickets map[string]int
+	// idNormalizer normalizes participant IDs. nil means no normalization.
+	idNormalizer IDNormalizer
+	// sequences contains IDs of drawn winners in pick order keyed by prize no.
+	sequences map[int][]string
+	// frozenPools contains IDs of the frozen pools keyed by prize no, see FreezePool.
+	frozenPools map[int][]string
+	// logger logs operations. nil means no logs.
+	logger *slog.Logger
+	// validator approves picked candidates before they become winners. nil means no validation.
+	validator Validator
+	// eligibility contains predicates of eligible participants keyed by prize no.
+	eligibility map[int]func(Participant) bool
+	// maxParticipants is the max number of participants. 0 means no limit.
+	maxParticipants int
+	// maxPrizes is the max number of prizes. 0 means no limit.
+	maxPrizes int
+	// poolCacheEnabled makes DrawOne cache the available participants, see SetPoolCache.
+	poolCacheEnabled bool
+	poolCache        *poolCache
+	// sampleRnd is the random generator of SampleOne which is separate from the draw's.
+	sampleRnd *rand.Rand
+	// expectedParticipants is the number of participants to pre-size the participants for, see Reserve.
+	expectedParticipants int
+	// strictLoad makes the loaders reject files without data rows.
+	strictLoad bool
+	// lastModified is the time of the last successful mutation.
+	lastModified time.Time
+	// dirty is set by mutations and cleared by SaveToFile and Load.
+	dirty bool
+	// mutations counts mutations, so SaveToFile only clears dirty if nothing changed while saving.
+	mutations uint64
+	// csvOptions are the options of CSV exports.
+	csvOptions CSVOptions
+	// saveValidators check the save data before Save writes it.
+	saveValidators []SaveValidator
+	// participantValidator checks participants before they're added.
+	participantValidator ParticipantValidator
+	// registrationDeadline is the time after which participants can't be added. Zero means no deadline.
+	registrationDeadline time.Time
+	// maxOutcomes is the max number of outcomes of PossibleOutcomes. 0 means DefaultMaxOutcomes.
+	maxOutcomes int
+	// maxDistinctWinners is the max number of distinct winners of the event. 0 means no limit.
+	maxDistinctWinners int
+	// maxDrawDuration is the max duration of the selection with a validator. 0 means no limit.
+	maxDrawDuration time.Duration
+	// conflictPolicy is the policy of the participants loaders for rows with the same ID.
+	conflictPolicy ConflictPolicy
+	// conflictResolver reconciles rows with the same ID for ConflictResolve.
+	conflictResolver ConflictResolver
+	// metadata is the event metadata (e.g. venue, date, organizer).
+	metadata map[string]string
+	// claimKey is the secret key to sign claim tokens.
+	claimKey []byte
+	// seedKey is the secret key to encrypt the committed seed in the save data.
+	seedKey []byte
+	// onPoolExhausted is called when a draw runs out of available participants.
+	onPoolExhausted func(prizeNo int, got, wanted int)
+	exhaustions     []poolExhaustion
+	// watchers receive the changes of winners, see WatchWinners.
+	watchers       map[int]chan DrawEvent
+	nextWatcherID  int
+	watchedWinners map[int][]Participant
 }
 
+// RevokedWinner is a revoked winner in the revoke history.
+type RevokedWinner struct {
+	Participant
+	Reason    string    `json:"reason,omitempty"`
+	RevokedAt time.Time `json:"revoked_at"`
+}
+
+type drawOp struct {
+	prizeNo int
+	winners []Participant
+}
+
+// SaveDataVersion is the version of SaveData written by Save.
+// Data of version 1 (the first release) has no version field.
+// Version 2 adds draw sequences.
+// Version 3 makes the checksum cover the state of the random source, so a tampered seed is detected.
+// Version 4 makes the checksum cover the audit log, the seed commitment, the finalized flag and the win settings.
+const SaveDataVersion = 4
+
 type SaveData struct {
-	Name         string                 `json:"name"`
-	Prizes       map[int]Prize          `json:"prizes"`
-	Participants map[string]Participant `json:"participants"`
-	Winners      map[int][]Participant  `json:"winners"`
-	LastUpdated  string                 `json:"last_updated"`
-	Checksum     string                 `json:"checksum"`
+	Version               int                     `json:"version,omitempty"`
+	Name                  string                  `json:"name"`
+	Prizes                map[int]Prize           `json:"prizes"`
+	Participants          map[string]Participant  `json:"participants"`
+	Winners               map[int][]Participant   `json:"winners"`
+	LastUpdated           string                  `json:"last_updated"`
+	Checksum              string                  `json:"checksum"`
+	MultiWin              bool                    `json:"multi_win,omitempty"`
+	MaxWinsPerParticipant int                     `json:"max_wins_per_participant,omitempty"`
+	RandState             *RandState              `json:"rand_state,omitempty"`
+	Finalized             bool                    `json:"finalized,omitempty"`
+	HashAlgorithm         string                  `json:"hash_algorithm,omitempty"`
+	Revoked               map[int][]RevokedWinner `json:"revoked,omitempty"`
+	Excluded              []string                `json:"excluded,omitempty"`
+	Alternates            map[int][]Participant   `json:"alternates,omitempty"`
+	Commitment            string                  `json:"commitment,omitempty"`
+	Raffle                bool                    `json:"raffle,omitempty"`
+	Tickets               map[string]int          `json:"tickets,omitempty"`
+	Sequences             map[int][]string        `json:"sequences,omitempty"`
+	Metadata              map[string]string       `json:"metadata,omitempty"`
+	FrozenPools           map[int][]string        `json:"frozen_pools,omitempty"`
+	Audit                 []AuditEntry            `json:"audit,omitempty"`
+	// SealedSeed is the committed seed encrypted by the seed key before it can be revealed.
+	// Then the seed of RandState is 0. See SetSeedKey.
+	SealedSeed string `json:"sealed_seed,omitempty"`
+}
+
+// RandState is the state of the random source of a draw.
+// Restoring the seed and skipping Count values makes the source
+// continue from where it was saved.
+type RandState struct {
+	// Algorithm is the algorithm of the source, see SetRandAlgorithm.
+	// Empty means RandGo1 for compatibility with old data files.
+	Algorithm string `json:"algorithm,omitempty"`
+	Seed      int64  `json:"seed"`
+	Count     uint64 `json:"count"`
+}
+
+// randSource wraps a seeded rand.Source and counts generated values
+// so its state can be saved and restored.
+type randSource struct {
+	src       rand.Source
+	algorithm string
+	seed      int64
+	count     uint64
+}
+
+// newRandSource returns a source of the algorithm with the seed.
+// The algorithm should be checked by newRandGenerator first.
+func newRandSource(algorithm string, seed int64) *randSource {
+	src, _ := newRandGenerator(algorithm, seed)
+	return &randSource{src, algorithm, seed, 0}
+}
+
+func (s *randSource) Int63() int64 {
+	s.count++
+	return s.src.Int63()
+}
+
+func (s *randSource) Seed(seed int64) {
+	s.src.Seed(seed)
+	s.seed = seed
+	s.count = 0
+}
+
+func (s *randSource) state() *RandState {
+	return &RandState{s.algorithm, s.seed, s.count}
+}
+
+// restoreRandSource restores the saved random source after checking the state, see checkRandState.
+// It returns nil if state is nil.
+func restoreRandSource(state *RandState) (*randSource, error) {
+	if state == nil {
+		return nil, nil
+	}
+
+	if err := checkRandState(state); err != nil {
+		return nil, err
+	}
+
+	s := newRandSource(state.Algorithm, state.Seed)
+	for s.count < state.Count {
+		s.Int63()
+	}
+	return s, nil
 }
 
 var (
@@ -56,328 +318,2329 @@ var (
 	ErrWinnersNotExistBeforeReDraw   = fmt.Errorf("winners don't exist before redraw")
 	ErrRedrawPrizeAmount             = fmt.Errorf("incorrect redraw prize amount")
 	ErrChecksum                      = fmt.Errorf("incorrect checksum")
+	ErrDataFileExists                = fmt.Errorf("data file already exists")
+	ErrParticipantNotFound           = fmt.Errorf("participant not found")
+	ErrOpKeyMismatch                 = fmt.Errorf("operation key was used for another prize")
+	ErrFinalized                     = fmt.Errorf("draw is finalized")
+	ErrParticipantExists             = fmt.Errorf("participant already exists")
+	ErrHashAlgorithm                 = fmt.Errorf("unsupported hash algorithm")
+	ErrConstraintUnsatisfiable       = fmt.Errorf("draw constraint can't be satisfied")
+	ErrPrizeExists                   = fmt.Errorf("prize already exists")
+	ErrWinnersExist                  = fmt.Errorf("winners already exist")
+	ErrNameMismatch                  = fmt.Errorf("name in data does not match the draw")
+	ErrWinnersCSV                    = fmt.Errorf("incorrect winners CSV")
+	ErrNoAlternates                  = fmt.Errorf("no alternates")
+	ErrAllCandidatesRejected         = fmt.Errorf("all candidates are rejected by the validator")
+	ErrUnknownWinner                 = fmt.Errorf("winner is not a participant")
+	ErrNoGroups                      = fmt.Errorf("no groups of participants")
+	ErrPrizeNotDrawable              = fmt.Errorf("prize is display-only and not drawable")
+	ErrNoCommitment                  = fmt.Errorf("seed is not committed")
+	ErrNotEnoughParticipants         = fmt.Errorf("not enough available participants")
+	ErrParticipantsLocked            = fmt.Errorf("participants are locked after drawing")
+	ErrPartialDraw                   = fmt.Errorf("not enough participants to draw the full amount")
+	ErrSaveDataVersion               = fmt.Errorf("unsupported save data version")
+	ErrPrizeFull                     = fmt.Errorf("prize is full")
+	ErrNoShowsCSV                    = fmt.Errorf("incorrect no-shows CSV")
+	ErrInconsistentState             = fmt.Errorf("inconsistent draw state")
+	ErrLimitExceeded                 = fmt.Errorf("limit of participants or prizes exceeded")
+	ErrDataNotFound                  = fmt.Errorf("data not found in store")
+	ErrNoDataRows                    = fmt.Errorf("no data rows")
+	ErrCannotFillPrize               = fmt.Errorf("not enough participants to fill the prize")
+	ErrCSVDelimiter                  = fmt.Errorf("invalid CSV delimiter")
+	ErrDrawTimeout                   = fmt.Errorf("draw timed out")
+	ErrNoClaimKey                    = fmt.Errorf("claim key is not set")
+	ErrNotWinner                     = fmt.Errorf("participant is not a winner of the prize")
+	ErrRegistrationClosed            = fmt.Errorf("registration is closed")
+	ErrTooManyOutcomes               = fmt.Errorf("too many possible outcomes")
+	ErrWinnerCapReached              = fmt.Errorf("max number of distinct winners reached")
+	ErrNoOperator                    = fmt.Errorf("no operator")
+	ErrPrizeValue                    = fmt.Errorf("invalid prize value")
+	ErrRandAlgorithm                 = fmt.Errorf("unsupported rand algorithm")
+	ErrSeedNotRevealed               = fmt.Errorf("seed can't be revealed before the draws are done")
+	ErrSeedKey                       = fmt.Errorf("missing or incorrect seed key")
+	ErrRandState                     = fmt.Errorf("invalid rand state")
+	ErrSeedCommitted                 = fmt.Errorf("seed is already committed")
+	ErrBusy                          = fmt.Errorf("draw of the prize is in progress")
 	AppDataDir                       string
 )
 
 func init() {
 }
 
+// New creates a draw with a random seed and DefaultRandAlgorithm, see Seed.
+// The seed comes from the securely auto-seeded generator of math/rand/v2, so it can't be guessed from the time.
 func New(name string) *Draw {
+	return newDraw(name, DefaultRandAlgorithm, newSeed())
+}
+
+// NewWithSeed creates a draw which uses its own random source with the given seed.
+// Draws with the same seed, participants and prizes produce the same winners.
+// It uses RandGo1, so the winners of a seed are the same as older versions of the package
+// and published seeds can still be reproduced. Use SetRandAlgorithm to use another algorithm.
+func NewWithSeed(name string, seed int64) *Draw {
+	return newDraw(name, RandGo1, seed)
+}
+
+// newDraw creates a draw whose random source uses the algorithm and the seed.
+func newDraw(name, algorithm string, seed int64) *Draw {
+	src := newRandSource(algorithm, seed)
 	l := &Draw{
-		name,
-		make(map[int]Prize),
-		make(map[string]Participant),
-		make(map[int][]Participant),
-		&sync.Mutex{},
+		name:         name,
+		prizes:       make(map[int]Prize),
+		participants: make(map[string]Participant),
+		winners:      make(map[int][]Participant),
+		revoked:      make(map[int][]RevokedWinner),
+		excluded:     make(map[string]bool),
+		alternates:   make(map[int][]Participant),
+		tickets:      make(map[string]int),
+		sequences:    make(map[int][]string),
+		frozenPools:  make(map[int][]string),
+		src:          src,
+		rnd:          rand.New(src),
 	}
 
 	return l
 }
 
-func (d *Draw) SetPrize(no int, name string, amount int, desc string) {
-	d.mutex.Lock()
-	defer d.mutex.Unlock()
+// ensureInit initializes nil maps and the random source,
+// so a zero value Draw (e.g. &Draw{}) works like a draw created by New with an empty name.
+// Mutating methods call it first, so it also invalidates the pool cache, see SetPoolCache.
+// The caller should hold the mutex.
+func (d *Draw) ensureInit() {
+	d.invalidatePool()
+	d.initState()
+}
 
-	prize := Prize{no, name, amount, desc}
-	d.prizes[no] = prize
+// initState initializes nil maps and the random source like ensureInit, but keeps the pool cache.
+// The caller should hold the mutex.
+func (d *Draw) initState() {
+	if d.prizes == nil {
+		d.prizes = make(map[int]Prize)
+	}
+
+	if d.participants == nil {
+		d.participants = make(map[string]Participant)
+	}
+
+	if d.winners == nil {
+		d.winners = make(map[int][]Participant)
+	}
+
+	if d.revoked == nil {
+		d.revoked = make(map[int][]RevokedWinner)
+	}
+
+	if d.excluded == nil {
+		d.excluded = make(map[string]bool)
+	}
+
+	if d.alternates == nil {
+		d.alternates = make(map[int][]Participant)
+	}
+
+	if d.tickets == nil {
+		d.tickets = make(map[string]int)
+	}
+
+	if d.sequences == nil {
+		d.sequences = make(map[int][]string)
+	}
+
+	if d.frozenPools == nil {
+		d.frozenPools = make(map[int][]string)
+	}
+
+	if d.src == nil {
+		d.src = newRandSource(DefaultRandAlgorithm, newSeed())
+		d.rnd = rand.New(d.src)
+	}
 }
 
-func (d *Draw) Prize(no int) Prize {
-	d.mutex.Lock()
-	defer d.mutex.Unlock()
+// Name returns the name of the draw.
+func (d *Draw) Name() string {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
 
-	return d.prizes[no]
+	return d.name
 }
 
-func (d *Draw) LoadPrizesCSV(r io.Reader) error {
+// Rename changes the name of the draw.
+// The data file name is derived from the name,
+// so if a data file exists for the old name, it'll be renamed too
+// and the name in the data file will be updated.
+// It returns ErrDataFileExists if a data file already exists for the new name.
+func (d *Draw) Rename(newName string) error {
+	d.fileMutex.Lock()
+	defer d.fileMutex.Unlock()
+
 	d.mutex.Lock()
 	defer d.mutex.Unlock()
 
-	reader := csv.NewReader(r)
-	rows, err := reader.ReadAll()
-	if err != nil {
-		return err
+	if d.finalized {
+		return ErrFinalized
 	}
 
-	d.prizes = make(map[int]Prize)
-	for i := 1; i < len(rows); i++ {
-		row := rows[i]
+	if newName == d.name {
+		return nil
+	}
 
-		if len(row) != 4 {
-			return ErrParticipantsCSV
-		}
-		no, err := strconv.Atoi(strings.Trim(row[0], " "))
-		if err != nil {
-			return err
+	oldFile := makeDataFileName(d.name)
+	newFile := makeDataFileName(newName)
+
+	if _, err := os.Stat(oldFile); err == nil {
+		if _, err := os.Stat(newFile); err == nil {
+			return ErrDataFileExists
 		}
-		name := row[1]
-		amount, err := strconv.Atoi(strings.Trim(row[2], " "))
-		if err != nil {
+		if err := renameDataFile(oldFile, newFile, newName); err != nil {
 			return err
 		}
-		desc := row[3]
-
-		d.prizes[no] = Prize{no, name, amount, desc}
 	}
+
+	d.name = newName
 	return nil
 }
 
-func (d *Draw) LoadPrizesCSVFile(file string) error {
-	f, err := os.Open(file)
+// renameDataFile writes the data in oldFile to newFile with the new name and removes oldFile.
+// The data is written in the format of oldFile (gzip compressed, indented or compact JSON).
+// The checksum doesn't cover the name, so it's still valid.
+func renameDataFile(oldFile, newFile, newName string) error {
+	buf, err := os.ReadFile(oldFile)
 	if err != nil {
 		return err
 	}
-	defer f.Close()
 
-	return d.LoadPrizesCSV(f)
-}
-
-func prizeMapToSlice(m map[int]Prize, descOrder bool) []Prize {
-	s := []int{}
-	prizes := []Prize{}
+	format, err := detectSaveFormat(buf)
+	if err != nil {
+		return err
+	}
 
-	// Sort prize map by key
-	for prizeNo, _ := range m {
-		s = append(s, prizeNo)
+	data := SaveData{}
+	if err := decodeSaveData(bytes.NewReader(buf), &data); err != nil {
+		return err
 	}
+	data.Name = newName
 
-	sort.Slice(s, func(i, j int) bool {
-		if descOrder {
-			return s[i] > s[j]
-		} else {
-			return s[i] < s[j]
-		}
-	})
+	out, err := encodeSaveData(&data, format)
+	if err != nil {
+		return err
+	}
 
-	for _, prizeNo := range s {
-		prizes = append(prizes, m[prizeNo])
+	if err := writeFileAtomic(newFile, out); err != nil {
+		return err
 	}
 
-	return prizes
+	return os.Remove(oldFile)
 }
 
-func (d *Draw) Prizes(descOrder bool) []Prize {
+// Finalize marks the draw as finalized.
+// After that, all methods which change prizes, participants or winners return ErrFinalized.
+// Read methods keep working.
+func (d *Draw) Finalize() {
+	defer d.mutated(nil)
+
 	d.mutex.Lock()
 	defer d.mutex.Unlock()
 
-	return prizeMapToSlice(d.prizes, descOrder)
+	d.ensureInit()
+
+	d.finalized = true
 }
 
-func (d *Draw) LoadParticipantsCSV(r io.Reader) error {
+// IsFinalized reports whether the draw is finalized.
+func (d *Draw) IsFinalized() bool {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	return d.finalized
+}
+
+// SetPrize sets the prize.
+// A prize with amount 0 is display-only: it's listed by Prizes, but Draw returns ErrPrizeNotDrawable.
+func (d *Draw) SetPrize(no int, name string, amount int, desc string) (err error) {
+	defer func() { d.mutated(err) }()
+
 	d.mutex.Lock()
 	defer d.mutex.Unlock()
 
-	reader := csv.NewReader(r)
-	rows, err := reader.ReadAll()
-	if err != nil {
+	d.ensureInit()
+
+	if d.finalized {
+		return ErrFinalized
+	}
+
+	if err := d.checkPrizeAmount(amount); err != nil {
 		return err
 	}
 
-	d.participants = make(map[string]Participant)
-	for i := 1; i < len(rows); i++ {
-		row := rows[i]
-		if len(row) != 2 {
-			return ErrParticipantsCSV
+	if _, ok := d.prizes[no]; !ok {
+		if err := d.checkPrizesLimit(len(d.prizes) + 1); err != nil {
+			return err
 		}
-		ID := row[0]
-		name := row[1]
-		d.participants[ID] = Participant{ID, name}
 	}
+
+	prize := Prize{No: no, Name: name, Amount: amount, Desc: desc}
+	d.prizes[no] = prize
 	return nil
 }
 
-func (d *Draw) LoadParticipantsCSVFile(file string) error {
-	f, err := os.Open(file)
-	if err != nil {
+// SetPrizes replaces all prizes.
+// Each prize no should be positive and unique and each amount should not be negative.
+// If a prize is invalid, it returns an error of the first invalid prize and no prize is changed.
+func (d *Draw) SetPrizes(prizes []Prize) (err error) {
+	defer func() { d.mutated(err) }()
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.ensureInit()
+
+	if d.finalized {
+		return ErrFinalized
+	}
+
+	m := make(map[int]Prize)
+	for _, prize := range prizes {
+		if prize.No <= 0 {
+			return fmt.Errorf("prize no %d: %w", prize.No, ErrPrizeNo)
+		}
+		if _, ok := m[prize.No]; ok {
+			return fmt.Errorf("prize no %d: %w", prize.No, ErrPrizeExists)
+		}
+		if err := d.checkPrizeAmount(prize.Amount); err != nil {
+			return fmt.Errorf("prize no %d: %w", prize.No, err)
+		}
+		if err := checkPercent(prize.Percent); err != nil {
+			return fmt.Errorf("prize no %d: %w", prize.No, err)
+		}
+		m[prize.No] = prize
+	}
+
+	if err := d.checkPrizesLimit(len(m)); err != nil {
 		return err
 	}
-	defer f.Close()
 
-	return d.LoadParticipantsCSV(f)
+	d.prizes = m
+	return nil
 }
 
-func participantMapToSlice(m map[string]Participant) []Participant {
-	participants := []Participant{}
+// TransferSlots moves count unfilled slots from one prize to another
+// (e.g. the pool ran dry for one prize and another has extra demand).
+// The amount of fromPrizeNo is decreased and the amount of toPrizeNo is increased by count.
+// It returns ErrRedrawPrizeAmount if fromPrizeNo has less than count unfilled slots,
+// so no prize goes below its winner count.
+func (d *Draw) TransferSlots(fromPrizeNo, toPrizeNo, count int) (err error) {
+	defer func() { d.mutated(err) }()
 
-	for _, p := range m {
-		participants = append(participants, p)
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.ensureInit()
+
+	if d.finalized {
+		return ErrFinalized
 	}
 
-	return participants
-}
+	from, ok := d.prizes[fromPrizeNo]
+	if !ok {
+		return fmt.Errorf("prize no %d: %w", fromPrizeNo, ErrPrizeNo)
+	}
 
-func participantSliceToMap(s []Participant) map[string]Participant {
-	m := make(map[string]Participant)
+	to, ok := d.prizes[toPrizeNo]
+	if !ok || toPrizeNo == fromPrizeNo {
+		return fmt.Errorf("prize no %d: %w", toPrizeNo, ErrPrizeNo)
+	}
 
-	for _, p := range s {
-		m[p.ID] = p
+	if count < 1 {
+		return ErrPrizeAmount
 	}
 
-	return m
+	if count > d.remainingSlots(fromPrizeNo) {
+		return fmt.Errorf("prize no %d: %w", fromPrizeNo, ErrRedrawPrizeAmount)
+	}
+
+	if err := d.checkPrizeAmount(to.Amount + count); err != nil {
+		return fmt.Errorf("prize no %d: %w", toPrizeNo, err)
+	}
+
+	from.Amount -= count
+	to.Amount += count
+	d.prizes[fromPrizeNo] = from
+	d.prizes[toPrizeNo] = to
+	return nil
 }
 
-func (d *Draw) Participants() []Participant {
+// SetMaxPrizeAmount sets the max amount of a prize which is checked
+// when prizes are set or loaded, so a typo in the amount column is caught early.
+// 0 (default) means no limit.
+func (d *Draw) SetMaxPrizeAmount(n int) {
 	d.mutex.Lock()
 	defer d.mutex.Unlock()
 
-	return participantMapToSlice(d.participants)
+	d.maxPrizeAmount = n
 }
 
-func copyParticipantMap(m map[string]Participant) map[string]Participant {
-	copiedMap := make(map[string]Participant)
-
-	for k, v := range m {
-		copiedMap[k] = v
+// checkDrawable returns ErrPrizeNotDrawable if the amount is 0 (display-only prize)
+// and ErrPrizeAmount if the amount is negative.
+func checkDrawable(amount int) error {
+	if amount == 0 {
+		return ErrPrizeNotDrawable
+	}
+	if amount < 0 {
+		return ErrPrizeAmount
 	}
+	return nil
+}
 
-	return copiedMap
+// checkPrizeAmount returns ErrPrizeAmount if the amount is negative or greater than the max amount.
+func (d *Draw) checkPrizeAmount(amount int) error {
+	if amount < 0 {
+		return fmt.Errorf("amount %d is negative: %w", amount, ErrPrizeAmount)
+	}
+	if d.maxPrizeAmount > 0 && amount > d.maxPrizeAmount {
+		return fmt.Errorf("amount %d exceeds max amount %d: %w", amount, d.maxPrizeAmount, ErrPrizeAmount)
+	}
+	return nil
 }
 
-func (d *Draw) availableParticipants(prizeNo int) []Participant {
-	participants := copyParticipantMap(d.participants)
+func (d *Draw) Prize(no int) Prize {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
 
-	// Remove winners
-	for _, winners := range d.winners {
-		for _, winner := range winners {
-			delete(participants, winner.ID)
-		}
-	}
+	return d.prizes[no]
+}
 
-	return participantMapToSlice(participants)
+// LookupPrize returns the prize with the given no and reports if it exists.
+func (d *Draw) LookupPrize(no int) (Prize, bool) {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	prize, ok := d.prizes[no]
+	return prize, ok
 }
 
-func (d *Draw) AvailableParticipants(prizeNo int) []Participant {
-	d.mutex.Lock()
-	defer d.mutex.Unlock()
+// LoadPrizesCSV loads prizes from CSV.
+// The first row is the header and the first 4 columns are required:
+// no, name, amount, desc.
+// Additional columns are stored in Prize.Extra keyed by their header names.
+// An amount like "5%" is a percentage of the participants, see SetPrizePercent.
+// It returns an error with the row number if a prize no is not positive or duplicated,
+// and existing prizes are kept in that case.
+func (d *Draw) LoadPrizesCSV(r io.Reader) error {
+	return d.LoadPrizesDelimited(r, ',')
+}
 
-	return d.availableParticipants(prizeNo)
+// LoadPrizesTSV loads prizes from tab-separated values like LoadPrizesCSV.
+func (d *Draw) LoadPrizesTSV(r io.Reader) error {
+	return d.LoadPrizesDelimited(r, '\t')
 }
 
-func (d *Draw) Winners(prizeNo int) []Participant {
+// LoadPrizesDelimited loads prizes like LoadPrizesCSV with the given field delimiter.
+func (d *Draw) LoadPrizesDelimited(r io.Reader, comma rune) (err error) {
+	defer func() { d.mutated(err) }()
+
 	d.mutex.Lock()
 	defer d.mutex.Unlock()
 
-	if _, ok := d.winners[prizeNo]; !ok {
-		return []Participant{}
-	}
-
-	return d.winners[prizeNo]
-}
+	d.ensureInit()
 
-func removeParticipant(s []Participant, i int) []Participant {
-	l := len(s)
-	if l <= 0 {
-		return s
+	if d.finalized {
+		return ErrFinalized
 	}
 
-	if i < 0 || i > l-1 {
-		return s
+	prizes, err := d.parsePrizesCSV(r, comma, nil)
+	if err != nil {
+		return err
 	}
 
-	s[i] = s[l-1]
-	return s[:l-1]
+	d.prizes = prizes
+	return nil
 }
 
-func draw(prizeAmount int, participants []Participant) []Participant {
-	winners := []Participant{}
-
-	if prizeAmount <= 0 || len(participants) <= 0 {
-		return winners
+func (d *Draw) LoadPrizesCSVFile(file string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
 	}
+	defer f.Close()
 
-	// Check prize amount.
-	amount := prizeAmount
-	// If participants amount < prize amount,
-	// use participants amount as the new prize amount.
-	if len(participants) < prizeAmount {
-		amount = len(participants)
-	}
+	return d.LoadPrizesCSV(f)
+}
 
-	for i := 0; i < amount; i++ {
-		rand.Seed(time.Now().UnixNano())
-		index := rand.Intn(len(participants))
-		winners = append(winners, participants[index])
-		participants = removeParticipant(participants, index)
+func (d *Draw) LoadPrizesTSVFile(file string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
 	}
+	defer f.Close()
 
-	return winners
+	return d.LoadPrizesTSV(f)
 }
 
-func (d *Draw) Draw(prizeNo int) ([]Participant, error) {
+// AppendPrizesCSV adds prizes in the CSV to existing prizes (e.g. prizes of several sponsors in separate files).
+// The format is the same as LoadPrizesCSV.
+// It returns ErrPrizeExists with the row number if a prize no already exists or is duplicated in the CSV,
+// and no prize is added in that case.
+func (d *Draw) AppendPrizesCSV(r io.Reader) (err error) {
+	defer func() { d.mutated(err) }()
+
 	d.mutex.Lock()
 	defer d.mutex.Unlock()
 
-	winners := []Participant{}
+	d.ensureInit()
 
-	if _, ok := d.prizes[prizeNo]; !ok {
-		return winners, ErrPrizeNo
+	if d.finalized {
+		return ErrFinalized
 	}
 
-	amount := d.prizes[prizeNo].Amount
-	if amount < 1 {
-		return winners, ErrPrizeAmount
+	prizes, err := d.parsePrizesCSV(r, ',', d.prizes)
+	if err != nil {
+		return err
 	}
 
-	if _, ok := d.winners[prizeNo]; ok {
-		return winners, ErrWinnersExistBeforeDraw
+	for no, prize := range prizes {
+		d.prizes[no] = prize
 	}
+	return nil
+}
 
-	participants := d.availableParticipants(prizeNo)
-	if len(participants) == 0 {
-		return winners, ErrNoAvailableParticipants
+func (d *Draw) AppendPrizesCSVFile(file string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return d.AppendPrizesCSV(f)
+}
+
+// parsePrizesCSV parses prizes from CSV with the given field delimiter.
+// Prize nos of existing prizes can't be used. existing can be nil.
+// The caller should hold the mutex.
+func (d *Draw) parsePrizesCSV(r io.Reader, comma rune, existing map[int]Prize) (map[int]Prize, error) {
+	reader := csv.NewReader(r)
+	reader.Comma = comma
+	// Rows are read one by one, so a huge file is rejected as soon as a limit is exceeded.
+	header, err := reader.Read()
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	prizes := make(map[int]Prize)
+	for i := 1; ; i++ {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if len(row) < 4 {
+			return nil, ErrParticipantsCSV
+		}
+		no, err := strconv.Atoi(strings.Trim(row[0], " "))
+		if err != nil {
+			return nil, err
+		}
+		// Prize no should be positive and unique.
+		if no <= 0 {
+			return nil, fmt.Errorf("row %d: prize no %d: %w", i+1, no, ErrPrizeNo)
+		}
+		if _, ok := prizes[no]; ok {
+			return nil, fmt.Errorf("row %d: prize no %d: %w", i+1, no, ErrPrizeExists)
+		}
+		if _, ok := existing[no]; ok {
+			return nil, fmt.Errorf("row %d: prize no %d: %w", i+1, no, ErrPrizeExists)
+		}
+		name := row[1]
+		amount, percent, err := parsePrizeAmount(strings.Trim(row[2], " "))
+		if err != nil {
+			return nil, err
+		}
+		if err := d.checkPrizeAmount(amount); err != nil {
+			return nil, fmt.Errorf("row %d: prize no %d: %w", i+1, no, err)
+		}
+		if err := checkPercent(percent); err != nil {
+			return nil, fmt.Errorf("row %d: prize no %d: %w", i+1, no, err)
+		}
+		desc := row[3]
+
+		var extra map[string]string
+		for j := 4; j < len(row) && j < len(header); j++ {
+			if extra == nil {
+				extra = make(map[string]string)
+			}
+			extra[strings.Trim(header[j], " ")] = row[j]
+		}
+
+		if err := d.checkPrizesLimit(len(existing) + len(prizes) + 1); err != nil {
+			return nil, fmt.Errorf("row %d: %w", i+1, err)
+		}
+		prizes[no] = Prize{No: no, Name: name, Amount: amount, Desc: desc, Extra: extra, Percent: percent}
+	}
+
+	if d.strictLoad && len(prizes) == 0 {
+		return nil, ErrNoDataRows
+	}
+
+	return prizes, nil
+}
+
+func prizeMapToSlice(m map[int]Prize, descOrder bool) []Prize {
+	s := []int{}
+	prizes := []Prize{}
+
+	// Sort prize map by key
+	for prizeNo, _ := range m {
+		s = append(s, prizeNo)
+	}
+
+	sort.Slice(s, func(i, j int) bool {
+		if descOrder {
+			return s[i] > s[j]
+		} else {
+			return s[i] < s[j]
+		}
+	})
+
+	for _, prizeNo := range s {
+		prizes = append(prizes, m[prizeNo])
+	}
+
+	return prizes
+}
+
+func (d *Draw) Prizes(descOrder bool) []Prize {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	return prizeMapToSlice(d.prizes, descOrder)
+}
+
+// remainingSlots returns the effective amount of the prize minus its winners, see effectiveAmount.
+// The caller should hold the mutex.
+func (d *Draw) remainingSlots(prizeNo int) int {
+	n := d.effectiveAmount(d.prizes[prizeNo]) - len(d.winners[prizeNo])
+	if n < 0 {
+		return 0
+	}
+	return n
+}
+
+// RemainingSlots returns the number of winners still to be drawn for the prize.
+func (d *Draw) RemainingSlots(prizeNo int) int {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	return d.remainingSlots(prizeNo)
+}
+
+// IsComplete reports if the winners of all prizes are full.
+// Display-only prizes (amount 0) are not counted.
+func (d *Draw) IsComplete() bool {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	for no, prize := range d.prizes {
+		if d.effectiveAmount(prize) > 0 && d.remainingSlots(no) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// PrizesByStatus returns the prizes whose winners are full (drawn is true)
+// or not full yet (drawn is false).
+// Prizes are sorted by prize no in ascending order.
+func (d *Draw) PrizesByStatus(drawn bool) []Prize {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	m := make(map[int]Prize)
+	for no, prize := range d.prizes {
+		if (d.remainingSlots(no) == 0) == drawn {
+			m[no] = prize
+		}
+	}
+
+	return prizeMapToSlice(m, false)
+}
+
+// LoadParticipantsCSV loads participants from CSV.
+// The first row is the header and the columns are: id, name, optional group,
+// followed by optional extra columns (e.g. email, phone) which are stored in Participant.Extra.
+// An extra column named "entries" is the number of entries of the participant (see Participant.Entries).
+// Names can be blank and a file with only the id column is accepted.
+// A leading UTF-8 BOM is ignored and surrounding whitespace of IDs and names is trimmed,
+// see SetTrimParticipantFields.
+// It returns an error with the row number if a row is invalid, an ID is empty
+// or duplicated (see SetConflictPolicy), and existing participants are kept in that case.
+func (d *Draw) LoadParticipantsCSV(r io.Reader) error {
+	return d.LoadParticipantsDelimited(r, ',')
+}
+
+// LoadParticipantsTSV loads participants from tab-separated values like LoadParticipantsCSV.
+// It's useful for data exported from databases where fields contain commas.
+func (d *Draw) LoadParticipantsTSV(r io.Reader) error {
+	return d.LoadParticipantsDelimited(r, '\t')
+}
+
+// LoadParticipantsDelimited loads participants like LoadParticipantsCSV with the given field delimiter.
+func (d *Draw) LoadParticipantsDelimited(r io.Reader, comma rune) (err error) {
+	defer func() { d.mutated(err) }()
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.ensureInit()
+
+	if d.finalized {
+		return ErrFinalized
+	}
+
+	if d.participantsLocked() {
+		return ErrParticipantsLocked
+	}
+
+	if d.registrationClosed() {
+		return ErrRegistrationClosed
+	}
+
+	participants, tickets, err := d.parseParticipantsCSV(r, comma)
+	if err != nil {
+		return err
+	}
+
+	d.participants = participants
+	d.tickets = tickets
+	return nil
+}
+
+// ValidateParticipantsCSV checks the participants CSV like LoadParticipantsCSV without loading it,
+// so errors (e.g. bad columns, empty or duplicate IDs) can be shown before the participants are replaced.
+func (d *Draw) ValidateParticipantsCSV(r io.Reader) error {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	_, _, err := d.parseParticipantsCSV(r, ',')
+	return err
+}
+
+// parseParticipantsCSV parses the participants CSV with the field delimiter and returns the participants and the tickets of raffle mode.
+// It returns an error with the row number if a row is invalid, an ID is empty
+// or an ID is duplicated and the conflict policy is ConflictError (see SetConflictPolicy).
+// The caller should hold the mutex.
+func (d *Draw) parseParticipantsCSV(r io.Reader, comma rune) (map[string]Participant, map[string]int, error) {
+	reader := csv.NewReader(skipBOM(r))
+	reader.Comma = comma
+	// Rows are read one by one, so a huge file is rejected as soon as a limit is exceeded.
+	header, err := reader.Read()
+	if err != nil && err != io.EOF {
+		return nil, nil, err
+	}
+
+	participants := make(map[string]Participant, d.expectedParticipants)
+	tickets := make(map[string]int)
+	for i := 1; ; i++ {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		p, err := d.parseParticipantRow(header, row)
+		if err != nil {
+			return nil, nil, fmt.Errorf("row %d: %w", i+1, err)
+		}
+
+		if p.ID == "" {
+			return nil, nil, fmt.Errorf("row %d: empty id: %w", i+1, ErrParticipantsCSV)
+		}
+
+		if err := d.validateParticipant(p); err != nil {
+			return nil, nil, fmt.Errorf("row %d: id %s: %w", i+1, p.ID, err)
+		}
+
+		// In raffle mode, each row is a ticket and the same ID can hold many tickets.
+		if d.raffle {
+			tickets[p.ID]++
+		} else if existing, ok := participants[p.ID]; ok {
+			if p, err = d.resolveConflict(existing, p); err != nil {
+				return nil, nil, fmt.Errorf("row %d: id %s: %w", i+1, existing.ID, err)
+			}
+		}
+
+		if _, ok := participants[p.ID]; !ok {
+			if err := d.checkParticipantsLimit(len(participants) + 1); err != nil {
+				return nil, nil, fmt.Errorf("row %d: %w", i+1, err)
+			}
+		}
+		participants[p.ID] = p
+	}
+
+	if d.strictLoad && len(participants) == 0 {
+		return nil, nil, ErrNoDataRows
+	}
+
+	return participants, tickets, nil
+}
+
+// SetStrictLoad sets if the prizes and participants loaders (e.g. LoadPrizesCSV, LoadParticipantsCSV)
+// return ErrNoDataRows for a file without data rows (empty or only a header),
+// so loading a wrong file is noticed immediately instead of at draw time.
+// It's false by default, because an empty file is sometimes intentional.
+func (d *Draw) SetStrictLoad(strict bool) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.strictLoad = strict
+}
+
+// SetUnlockParticipants sets if participants can be changed after drawing.
+// By default, LoadParticipantsCSV, AppendParticipantsCSV, SetParticipants, AddParticipant, RemoveParticipant and Merge
+// return ErrParticipantsLocked once any winners exist, so the pool is not changed accidentally during the event.
+func (d *Draw) SetUnlockParticipants(unlock bool) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.unlockParticipants = unlock
+}
+
+// participantsLocked reports if participants can't be changed because winners exist.
+// The caller should hold the mutex.
+func (d *Draw) participantsLocked() bool {
+	if d.unlockParticipants {
+		return false
+	}
+
+	for _, winners := range d.winners {
+		if len(winners) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// SetTrimParticipantFields sets if the participants CSV loaders trim surrounding whitespace of IDs and names.
+// It's true by default, because cells exported by spreadsheets often have trailing spaces.
+// Set it to false if IDs are intentionally spaced.
+func (d *Draw) SetTrimParticipantFields(trim bool) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.keepParticipantSpaces = !trim
+}
+
+// skipBOM returns a reader which skips the UTF-8 BOM at the beginning of r.
+// Files exported by Excel often begin with a BOM.
+func skipBOM(r io.Reader) io.Reader {
+	br := bufio.NewReader(r)
+	if b, err := br.Peek(3); err == nil && string(b) == "\xEF\xBB\xBF" {
+		br.Discard(3)
+	}
+	return br
+}
+
+// parseParticipantRow parses a row of the participants CSV.
+// The first column is required: id. The name column is optional and it can be blank.
+// The optional third column is the group and additional columns are stored in Participant.Extra
+// keyed by their header names, except the entries column.
+// The caller should hold the mutex.
+func (d *Draw) parseParticipantRow(header, row []string) (Participant, error) {
+	if len(row) < 1 {
+		return Participant{}, ErrParticipantsCSV
+	}
+
+	p := Participant{ID: row[0]}
+	if len(row) >= 2 {
+		p.Name = row[1]
+	}
+	if !d.keepParticipantSpaces {
+		p.ID = strings.TrimSpace(p.ID)
+		p.Name = strings.TrimSpace(p.Name)
+	}
+	p.ID = d.normalizeID(p.ID)
+	if len(row) >= 3 {
+		p.Group = row[2]
+	}
+
+	for j := 3; j < len(row) && j < len(header); j++ {
+		name := strings.Trim(header[j], " ")
+		if name == entriesColumn {
+			if v := strings.TrimSpace(row[j]); v != "" {
+				entries, err := strconv.Atoi(v)
+				if err != nil {
+					return Participant{}, fmt.Errorf("entries %q: %w", row[j], ErrParticipantsCSV)
+				}
+				p.Entries = entries
+			}
+			continue
+		}
+
+		if p.Extra == nil {
+			p.Extra = make(map[string]string)
+		}
+		p.Extra[name] = row[j]
+	}
+
+	return p, nil
+}
+
+// entriesColumn is the header of the column of Participant.Entries in the participants CSV.
+const entriesColumn = "entries"
+
+func (d *Draw) LoadParticipantsCSVFile(file string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return d.LoadParticipantsCSV(f)
+}
+
+func (d *Draw) LoadParticipantsTSVFile(file string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return d.LoadParticipantsTSV(f)
+}
+
+// AppendParticipantsCSV adds participants in the CSV to existing participants.
+// If overwrite is false, it returns ErrParticipantExists when an ID already exists
+// and no participant is added.
+// If overwrite is true, existing participants with the same IDs are replaced.
+// Rows with the same ID in the CSV are handled by the conflict policy, see SetConflictPolicy.
+func (d *Draw) AppendParticipantsCSV(r io.Reader, overwrite bool) (err error) {
+	defer func() { d.mutated(err) }()
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.ensureInit()
+
+	if d.finalized {
+		return ErrFinalized
+	}
+
+	if d.participantsLocked() {
+		return ErrParticipantsLocked
+	}
+
+	if d.registrationClosed() {
+		return ErrRegistrationClosed
+	}
+
+	reader := csv.NewReader(skipBOM(r))
+	// Rows are read one by one, so a huge file is rejected as soon as a limit is exceeded.
+	header, err := reader.Read()
+	if err != nil && err != io.EOF {
+		return err
+	}
+
+	participants := make(map[string]Participant)
+	added := 0
+	for i := 1; ; i++ {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		p, err := d.parseParticipantRow(header, row)
+		if err != nil {
+			return err
+		}
+
+		if err := d.validateParticipant(p); err != nil {
+			return fmt.Errorf("row %d: id %s: %w", i+1, p.ID, err)
+		}
+
+		_, exists := d.participants[p.ID]
+		if exists && !overwrite {
+			return ErrParticipantExists
+		}
+
+		if first, ok := participants[p.ID]; ok {
+			if p, err = d.resolveConflict(first, p); err != nil {
+				return fmt.Errorf("row %d: id %s: %w", i+1, first.ID, err)
+			}
+		}
+
+		if _, ok := participants[p.ID]; !ok && !exists {
+			added++
+			if err := d.checkParticipantsLimit(len(d.participants) + added); err != nil {
+				return fmt.Errorf("row %d: %w", i+1, err)
+			}
+		}
+		participants[p.ID] = p
+	}
+
+	for ID, p := range participants {
+		d.participants[ID] = p
+	}
+	return nil
+}
+
+func (d *Draw) AppendParticipantsCSVFile(file string, overwrite bool) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return d.AppendParticipantsCSV(f, overwrite)
+}
+
+func participantMapToSlice(m map[string]Participant) []Participant {
+	participants := make([]Participant, 0, len(m))
+
+	for _, p := range m {
+		participants = append(participants, p)
+	}
+
+	// Sort by ID so the order doesn't depend on map iteration.
+	sort.Slice(participants, func(i, j int) bool {
+		return participants[i].ID < participants[j].ID
+	})
+
+	return participants
+}
+
+func participantSliceToMap(s []Participant) map[string]Participant {
+	m := make(map[string]Participant)
+
+	for _, p := range s {
+		m[p.ID] = p
+	}
+
+	return m
+}
+
+func (d *Draw) Participants() []Participant {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	return participantMapToSlice(d.participants)
+}
+
+// Participant returns the participant with the given ID.
+func (d *Draw) Participant(id string) (Participant, bool) {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	id = d.normalizeID(id)
+
+	p, ok := d.participants[id]
+	return p, ok
+}
+
+// SetParticipants replaces all participants.
+func (d *Draw) SetParticipants(participants []Participant) (err error) {
+	defer func() { d.mutated(err) }()
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.ensureInit()
+
+	if d.finalized {
+		return ErrFinalized
+	}
+
+	if d.participantsLocked() {
+		return ErrParticipantsLocked
+	}
+
+	if d.registrationClosed() {
+		return ErrRegistrationClosed
+	}
+
+	m := make(map[string]Participant)
+	for _, p := range participants {
+		p.ID = d.normalizeID(p.ID)
+		if err := d.validateParticipant(p); err != nil {
+			return fmt.Errorf("id %s: %w", p.ID, err)
+		}
+		m[p.ID] = p
+	}
+
+	if err := d.checkParticipantsLimit(len(m)); err != nil {
+		return err
+	}
+
+	d.participants = m
+	return nil
+}
+
+// AddParticipant adds a participant.
+// It returns ErrParticipantExists if the ID is already used.
+func (d *Draw) AddParticipant(p Participant) (err error) {
+	defer func() { d.mutated(err) }()
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.ensureInit()
+
+	p.ID = d.normalizeID(p.ID)
+
+	if d.finalized {
+		return ErrFinalized
+	}
+
+	if d.participantsLocked() {
+		return ErrParticipantsLocked
+	}
+
+	if d.registrationClosed() {
+		return ErrRegistrationClosed
+	}
+
+	if _, ok := d.participants[p.ID]; ok {
+		return ErrParticipantExists
+	}
+
+	if err := d.validateParticipant(p); err != nil {
+		return err
+	}
+
+	if err := d.checkParticipantsLimit(len(d.participants) + 1); err != nil {
+		return err
+	}
+
+	d.participants[p.ID] = p
+	return nil
+}
+
+// RemoveParticipant removes the participant with the given ID.
+// It returns ErrWinnersExist if the participant is a winner,
+// because winners who are not participants can't be loaded, see Load.
+// Revoke the participant first.
+func (d *Draw) RemoveParticipant(id string) (err error) {
+	defer func() { d.mutated(err) }()
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.ensureInit()
+
+	id = d.normalizeID(id)
+
+	if d.finalized {
+		return ErrFinalized
+	}
+
+	if d.participantsLocked() {
+		return ErrParticipantsLocked
+	}
+
+	if _, ok := d.participants[id]; !ok {
+		return ErrParticipantNotFound
+	}
+
+	for _, winners := range d.winners {
+		if containsParticipant(winners, id) {
+			return ErrWinnersExist
+		}
+	}
+
+	delete(d.participants, id)
+	return nil
+}
+
+// UpdateParticipantName updates the name of the participant.
+// Winners store copies of participants, so the name of the winner will be updated too.
+func (d *Draw) UpdateParticipantName(id, name string) (err error) {
+	defer func() { d.mutated(err) }()
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.ensureInit()
+
+	id = d.normalizeID(id)
+
+	if d.finalized {
+		return ErrFinalized
+	}
+
+	p, ok := d.participants[id]
+	if !ok {
+		return ErrParticipantNotFound
+	}
+
+	p.Name = name
+	if err := d.validateParticipant(p); err != nil {
+		return err
+	}
+	d.participants[id] = p
+
+	for _, winners := range d.winners {
+		for i := range winners {
+			if winners[i].ID == id {
+				winners[i].Name = name
+			}
+		}
+	}
+
+	return nil
+}
+
+func copyParticipantMap(m map[string]Participant) map[string]Participant {
+	copiedMap := make(map[string]Participant, len(m))
+
+	for k, v := range m {
+		copiedMap[k] = v
+	}
+
+	return copiedMap
+}
+
+// copyParticipants returns a copy of s,
+// so the caller can't change the internal slices (e.g. winners) and vice versa.
+func copyParticipants(s []Participant) []Participant {
+	copied := make([]Participant, len(s))
+	copy(copied, s)
+	return copied
+}
+
+// SetMultiWin sets if a participant can win more than one prize.
+// Even if it's on, a participant can't win the same prize twice.
+// It returns ErrFinalized if the draw is finalized.
+func (d *Draw) SetMultiWin(multiWin bool) (err error) {
+	defer func() { d.mutated(err) }()
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.finalized {
+		return ErrFinalized
+	}
+
+	d.multiWin = multiWin
+	d.invalidatePool()
+	return nil
+}
+
+// SetMaxWinsPerParticipant sets the max number of prizes a participant can win
+// across all prizes when multi-win is on. 0 means no cap.
+// It returns ErrFinalized if the draw is finalized.
+func (d *Draw) SetMaxWinsPerParticipant(n int) (err error) {
+	defer func() { d.mutated(err) }()
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.finalized {
+		return ErrFinalized
+	}
+
+	d.maxWinsPerParticipant = n
+	d.invalidatePool()
+	return nil
+}
+
+func (d *Draw) availableParticipants(prizeNo int) []Participant {
+	participants := copyParticipantMap(d.participants)
+
+	// Remove excluded participants.
+	for id := range d.excluded {
+		delete(participants, id)
+	}
+
+	// Remove inactive participants.
+	for id, p := range participants {
+		if p.Inactive {
+			delete(participants, id)
+		}
+	}
+
+	// Remove participants who are not in the frozen pool.
+	if ids, ok := d.frozenPools[prizeNo]; ok {
+		frozen := make(map[string]bool, len(ids))
+		for _, id := range ids {
+			frozen[id] = true
+		}
+		for id := range participants {
+			if !frozen[id] {
+				delete(participants, id)
+			}
+		}
+	}
+
+	// Remove participants who are not eligible for the prize.
+	if eligible := d.eligibility[prizeNo]; eligible != nil {
+		for id, p := range participants {
+			if !eligible(p) {
+				delete(participants, id)
+			}
+		}
+	}
+
+	// Remove alternates of all prizes.
+	for _, alternates := range d.alternates {
+		for _, p := range alternates {
+			delete(participants, p.ID)
+		}
+	}
+
+	// Consolation prizes are for non-winners only.
+	if d.prizes[prizeNo].Consolation {
+		removeWinners(participants, d.winners)
+		return participantMapToSlice(participants)
+	}
+
+	// Winners are not removed in raffle mode: tickets are drawn with replacement.
+	if d.raffle {
+		return participantMapToSlice(participants)
+	}
+
+	if !d.multiWin {
+		removeWinners(participants, d.winners)
+		return participantMapToSlice(participants)
+	}
+
+	// Remove winners of the prize.
+	for _, winner := range d.winners[prizeNo] {
+		delete(participants, winner.ID)
+	}
+
+	// Remove participants who reach the max wins.
+	if d.maxWinsPerParticipant > 0 {
+		wins := make(map[string]int)
+		for _, winners := range d.winners {
+			for _, winner := range winners {
+				wins[winner.ID]++
+			}
+		}
+
+		for id, n := range wins {
+			if n >= d.maxWinsPerParticipant {
+				delete(participants, id)
+			}
+		}
+	}
+
+	return participantMapToSlice(participants)
+}
+
+func (d *Draw) AvailableParticipants(prizeNo int) []Participant {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	return d.availableParticipants(prizeNo)
+}
+
+// Winners returns the winners of the prize in draw order.
+// Revoke removes revoked winners in place, so remaining winners keep their positions,
+// and Redraw appends new winners to the end.
+func (d *Draw) Winners(prizeNo int) []Participant {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	if _, ok := d.winners[prizeNo]; !ok {
+		return []Participant{}
+	}
+
+	return copyParticipants(d.winners[prizeNo])
+}
+
+// WinningsOf returns the prizes won by the participant with the given ID.
+// Prizes are sorted by prize no in ascending order.
+func (d *Draw) WinningsOf(id string) []Prize {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	id = d.normalizeID(id)
+
+	m := make(map[int]Prize)
+	for prizeNo, winners := range d.winners {
+		if containsParticipant(winners, id) {
+			m[prizeNo] = d.prizes[prizeNo]
+		}
+	}
+
+	return prizeMapToSlice(m, false)
+}
+
+// ParticipantWinnings is a winner with the prizes won.
+type ParticipantWinnings struct {
+	Participant Participant `json:"participant"`
+	Prizes      []Prize     `json:"prizes"`
+}
+
+// WinnersByParticipant returns the winners with the prizes they won keyed by participant ID.
+// It's the transpose of AllWinners, e.g. to email each winner their prizes.
+// Prizes of each winner are sorted by prize no in ascending order like WinningsOf.
+func (d *Draw) WinnersByParticipant() map[string]ParticipantWinnings {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	prizes := make(map[string]map[int]Prize)
+	winners := make(map[string]Participant)
+	for prizeNo, s := range d.winners {
+		for _, winner := range s {
+			if prizes[winner.ID] == nil {
+				prizes[winner.ID] = make(map[int]Prize)
+			}
+			prizes[winner.ID][prizeNo] = d.prizes[prizeNo]
+			winners[winner.ID] = winner
+		}
+	}
+
+	m := make(map[string]ParticipantWinnings)
+	for id, winner := range winners {
+		m[id] = ParticipantWinnings{winner, prizeMapToSlice(prizes[id], false)}
+	}
+
+	return m
+}
+
+func containsParticipant(s []Participant, id string) bool {
+	for _, p := range s {
+		if p.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// IsWinner reports whether the participant with the given ID has won any prize.
+func (d *Draw) IsWinner(id string) bool {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	id = d.normalizeID(id)
+
+	return d.winCounts[id] > 0
+}
+
+// IsWinnerOf reports whether the participant with the given ID has won the prize.
+func (d *Draw) IsWinnerOf(prizeNo int, id string) bool {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	id = d.normalizeID(id)
+
+	return d.winnerIndex[prizeNo][id]
+}
+
+// draw selects up to prizeAmount winners from participants.
+//
+// It uses a partial Fisher–Yates shuffle on a copy of participants:
+// for each position i in [0, k), the element at i is swapped with an
+// element chosen uniformly from [i, n), and the first k elements are
+// returned as winners in the order they were picked.
+// Every participant has the same chance to be picked and the caller's
+// slice is left untouched.
+func draw(r *rand.Rand, prizeAmount int, participants []Participant) []Participant {
+	winners := []Participant{}
+
+	if prizeAmount <= 0 || len(participants) <= 0 {
+		return winners
+	}
+
+	// Check prize amount.
+	amount := prizeAmount
+	// If participants amount < prize amount,
+	// use participants amount as the new prize amount.
+	if len(participants) < prizeAmount {
+		amount = len(participants)
+	}
+
+	pool := make([]Participant, len(participants))
+	copy(pool, participants)
+
+	for i := 0; i < amount; i++ {
+		j := i + r.Intn(len(pool)-i)
+		pool[i], pool[j] = pool[j], pool[i]
+	}
+
+	return append(winners, pool[:amount]...)
+}
+
+// drawUniqueGroups selects up to prizeAmount winners from participants
+// and picks at most one winner from each group.
+// Groups in usedGroups are already taken by existing winners.
+// It works like draw, but a candidate whose group is taken is discarded
+// and the next one is picked until the winners are full or participants run out.
+// It returns ErrConstraintUnsatisfiable if there're not enough groups to fill the winners.
+func drawUniqueGroups(r *rand.Rand, prizeAmount int, participants []Participant, usedGroups map[string]bool) ([]Participant, error) {
+	winners := []Participant{}
+
+	if prizeAmount <= 0 || len(participants) <= 0 {
+		return winners, nil
+	}
+
+	amount := prizeAmount
+	if len(participants) < prizeAmount {
+		amount = len(participants)
+	}
+
+	pool := make([]Participant, len(participants))
+	copy(pool, participants)
+
+	used := make(map[string]bool)
+	for group := range usedGroups {
+		used[group] = true
+	}
+
+	for i := 0; i < len(pool) && len(winners) < amount; i++ {
+		j := i + r.Intn(len(pool)-i)
+		pool[i], pool[j] = pool[j], pool[i]
+
+		p := pool[i]
+		if p.Group != "" {
+			if used[p.Group] {
+				continue
+			}
+			used[p.Group] = true
+		}
+		winners = append(winners, p)
+	}
+
+	if len(winners) < amount {
+		return []Participant{}, ErrConstraintUnsatisfiable
+	}
+
+	return winners, nil
+}
+
+// selectWinners selects winners of the prize from participants with the prize's constraints
+// and the max number of distinct winners.
+// The caller should hold the mutex.
+func (d *Draw) selectWinners(r *rand.Rand, prizeNo, amount int, participants []Participant) ([]Participant, error) {
+	if err := d.checkWinnerCap(); err != nil {
+		return []Participant{}, err
+	}
+
+	winners, err := d.selectWinnersOfPrize(r, prizeNo, amount, participants)
+	if err != nil {
+		return winners, err
+	}
+
+	return d.capDistinctWinners(winners), nil
+}
+
+// selectWinnersOfPrize selects winners of the prize from participants with the prize's constraints.
+// The caller should hold the mutex.
+func (d *Draw) selectWinnersOfPrize(r *rand.Rand, prizeNo, amount int, participants []Participant) ([]Participant, error) {
+	var used map[string]bool
+	if d.prizes[prizeNo].UniqueGroups {
+		used = make(map[string]bool)
+		for _, winner := range d.winners[prizeNo] {
+			if winner.Group != "" {
+				used[winner.Group] = true
+			}
+		}
+	}
+
+	if d.validator != nil {
+		return d.selectValidWinners(r, d.prizes[prizeNo], amount, participants, used)
+	}
+
+	return d.pickWinners(r, amount, participants, used)
+}
+
+// pickWinners picks up to amount winners from participants with the draw's weights.
+// If used is not nil, it picks at most one winner from each group which is not in used.
+func (d *Draw) pickWinners(r *rand.Rand, amount int, participants []Participant, used map[string]bool) ([]Participant, error) {
+	if d.raffle {
+		return d.drawRaffle(r, amount, participants), nil
+	}
+
+	if weight := d.effectiveWeightFunc(participants); weight != nil {
+		return drawWeighted(r, amount, participants, weight, used)
+	}
+
+	if used != nil {
+		return drawUniqueGroups(r, amount, participants, used)
+	}
+
+	return draw(r, amount, participants), nil
+}
+
+// SetPrizeUniqueGroups sets if the prize picks at most one winner from each group.
+func (d *Draw) SetPrizeUniqueGroups(prizeNo int, uniqueGroups bool) (err error) {
+	defer func() { d.mutated(err) }()
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.ensureInit()
+
+	if d.finalized {
+		return ErrFinalized
+	}
+
+	prize, ok := d.prizes[prizeNo]
+	if !ok {
+		return ErrPrizeNo
+	}
+
+	prize.UniqueGroups = uniqueGroups
+	d.prizes[prizeNo] = prize
+	return nil
+}
+
+func (d *Draw) Draw(prizeNo int) (winners []Participant, err error) {
+	defer func() { d.mutated(err) }()
+	defer func() { d.observeDraw(prizeNo, winners, err) }()
+	defer func(start time.Time) {
+		d.logOp("Draw", start, err, "prize_no", prizeNo, "winners", len(winners))
+	}(time.Now())
+
+	d.beginDraw(prizeNo, false)
+	defer d.endDraw(prizeNo)
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.ensureInit()
+
+	if d.finalized {
+		return []Participant{}, ErrFinalized
+	}
+
+	return d.drawPrize(prizeNo)
+}
+
+// DrawIdempotent draws the prize like Draw and records the operation key.
+// If it's called again with the same key, it returns the winners drawn by the first call
+// instead of ErrWinnersExistBeforeDraw, so a retried request is safe.
+// Operation keys are kept in memory only.
+// A replayed call changes nothing, so the hooks (e.g. metrics and auto save) are not fired again.
+func (d *Draw) DrawIdempotent(prizeNo int, opKey string) (winners []Participant, err error) {
+	replayed := false
+
+	defer func() {
+		if replayed {
+			return
+		}
+		d.observeDraw(prizeNo, winners, err)
+		d.mutated(err)
+	}()
+	defer func(start time.Time) {
+		d.logOp("DrawIdempotent", start, err, "prize_no", prizeNo, "winners", len(winners), "replayed", replayed)
+	}(time.Now())
+
+	d.beginDraw(prizeNo, false)
+	defer d.endDraw(prizeNo)
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.ensureInit()
+
+	if d.finalized {
+		return []Participant{}, ErrFinalized
+	}
+
+	if op, ok := d.drawOps[opKey]; ok {
+		if op.prizeNo != prizeNo {
+			return []Participant{}, ErrOpKeyMismatch
+		}
+		replayed = true
+		return copyParticipants(op.winners), nil
+	}
+
+	winners, err = d.drawPrize(prizeNo)
+	if err != nil {
+		return winners, err
+	}
+
+	if d.drawOps == nil {
+		d.drawOps = make(map[string]drawOp)
+	}
+	d.drawOps[opKey] = drawOp{prizeNo, winners}
+	return winners, nil
+}
+
+// TryDraw draws the prize like Draw unless a draw of the prize is in progress (see DrawInProgress)
+// or the prize is already drawn, so a UI can handle a double click.
+// It returns (false, nil, ErrBusy) immediately if a draw of the prize (e.g. by Draw or another TryDraw) is in progress,
+// and (false, nil, nil) if the prize is already drawn. Nothing is changed in both cases.
+// Unlike a busy draw, readers of the draw (e.g. AllWinners) only delay it.
+// A busy call doesn't fire the hooks (e.g. metrics and logs), because they wait for the draw in progress.
+func (d *Draw) TryDraw(prizeNo int) (drawn bool, winners []Participant, err error) {
+	if !d.beginDraw(prizeNo, true) {
+		return false, nil, ErrBusy
+	}
+	defer d.endDraw(prizeNo)
+
+	defer func() {
+		if !drawn && err == nil {
+			return
+		}
+		d.observeDraw(prizeNo, winners, err)
+		d.mutated(err)
+	}()
+	defer func(start time.Time) {
+		d.logOp("TryDraw", start, err, "prize_no", prizeNo, "drawn", drawn, "winners", len(winners))
+	}(time.Now())
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.ensureInit()
+
+	if d.finalized {
+		return false, nil, ErrFinalized
+	}
+
+	if _, ok := d.winners[prizeNo]; ok {
+		return false, nil, nil
+	}
+
+	if winners, err = d.drawPrize(prizeNo); err != nil {
+		return false, nil, err
+	}
+
+	return true, winners, nil
+}
+
+// DrawInProgress reports whether a draw of the prize by Draw, DrawIdempotent or TryDraw is in progress.
+func (d *Draw) DrawInProgress(prizeNo int) bool {
+	d.drawingMutex.Lock()
+	defer d.drawingMutex.Unlock()
+
+	return d.drawing[prizeNo] > 0
+}
+
+// beginDraw marks a draw of the prize in progress.
+// If exclusive is true, it returns false and marks nothing if another draw of the prize is in progress.
+// It must be called without holding mutex and followed by endDraw if it returns true.
+func (d *Draw) beginDraw(prizeNo int, exclusive bool) bool {
+	d.drawingMutex.Lock()
+	defer d.drawingMutex.Unlock()
+
+	if exclusive && d.drawing[prizeNo] > 0 {
+		return false
+	}
+
+	if d.drawing == nil {
+		d.drawing = make(map[int]int)
+	}
+	d.drawing[prizeNo]++
+	return true
+}
+
+// endDraw marks a draw of the prize started by beginDraw done.
+func (d *Draw) endDraw(prizeNo int) {
+	d.drawingMutex.Lock()
+	defer d.drawingMutex.Unlock()
+
+	if d.drawing[prizeNo]--; d.drawing[prizeNo] <= 0 {
+		delete(d.drawing, prizeNo)
+	}
+}
+
+// drawPrize draws the winners of the prize.
+// The caller should hold the mutex.
+func (d *Draw) drawPrize(prizeNo int) ([]Participant, error) {
+	return d.drawPrizeWith(d.rnd, prizeNo, nil)
+}
+
+// drawPrizeWith draws the winners of the prize with the given random generator.
+// If pool is not nil, only available participants whose IDs are in pool can win.
+// The caller should hold the mutex.
+func (d *Draw) drawPrizeWith(r *rand.Rand, prizeNo int, pool map[string]bool) ([]Participant, error) {
+	winners := []Participant{}
+
+	if _, ok := d.prizes[prizeNo]; !ok {
+		return winners, ErrPrizeNo
+	}
+
+	prize := d.resolvePercentAmount(prizeNo)
+	amount := prize.Amount
+	if err := checkDrawable(amount); err != nil {
+		return winners, err
+	}
+
+	if _, ok := d.winners[prizeNo]; ok {
+		return winners, ErrWinnersExistBeforeDraw
+	}
+
+	participants := d.availableParticipants(prizeNo)
+	if pool != nil {
+		candidates := []Participant{}
+		for _, p := range participants {
+			if pool[p.ID] {
+				candidates = append(candidates, p)
+			}
+		}
+		participants = candidates
+	}
+
+	if len(participants) == 0 {
+		d.notePoolExhausted(prizeNo, 0, amount)
+		return winners, ErrNoAvailableParticipants
+	}
+
+	winners, err := d.selectWinners(r, prizeNo, amount, participants)
+	if err != nil {
+		return winners, err
+	}
+
+	if len(winners) < amount {
+		d.notePoolExhausted(prizeNo, len(winners), amount)
+	}
+
+	d.prizes[prizeNo] = prize
+	d.winners[prizeNo] = copyParticipants(winners)
+	d.indexWinners(prizeNo)
+	d.recordSequence(prizeNo, winners)
+	return winners, nil
+}
+
+// DrawFrom draws the prize like Draw, but only participants in pool
+// (e.g. people in the room right now) can win.
+// Participants of the draw are not changed and winners and excluded participants are still not available.
+// Participants in pool are matched by ID and it returns ErrParticipantNotFound
+// if a participant in pool is not a participant of the draw.
+func (d *Draw) DrawFrom(prizeNo int, pool []Participant) (winners []Participant, err error) {
+	defer func() { d.mutated(err) }()
+	defer func() { d.observeDraw(prizeNo, winners, err) }()
+	defer func(start time.Time) {
+		d.logOp("DrawFrom", start, err, "prize_no", prizeNo, "winners", len(winners))
+	}(time.Now())
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.ensureInit()
+
+	if d.finalized {
+		return []Participant{}, ErrFinalized
+	}
+
+	ids := make(map[string]bool)
+	for _, p := range pool {
+		id := d.normalizeID(p.ID)
+		if _, ok := d.participants[id]; !ok {
+			return []Participant{}, fmt.Errorf("id %s: %w", id, ErrParticipantNotFound)
+		}
+		ids[id] = true
+	}
+
+	return d.drawPrizeWith(d.rnd, prizeNo, ids)
+}
+
+// DrawExcluding draws the prize like Draw, but participants with the given IDs can't win
+// (e.g. people absent right now).
+// The exclusion is for this draw only and the excluded participants of the draw (see Exclude) are not changed.
+// IDs which are not participants are ignored.
+func (d *Draw) DrawExcluding(prizeNo int, excludeIDs []string) (winners []Participant, err error) {
+	defer func() { d.mutated(err) }()
+	defer func() { d.observeDraw(prizeNo, winners, err) }()
+	defer func(start time.Time) {
+		d.logOp("DrawExcluding", start, err, "prize_no", prizeNo, "winners", len(winners))
+	}(time.Now())
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.ensureInit()
+
+	if d.finalized {
+		return []Participant{}, ErrFinalized
+	}
+
+	excluded := make(map[string]bool)
+	for _, id := range excludeIDs {
+		excluded[d.normalizeID(id)] = true
+	}
+
+	pool := make(map[string]bool)
+	for id := range d.participants {
+		if !excluded[id] {
+			pool[id] = true
+		}
+	}
+
+	return d.drawPrizeWith(d.rnd, prizeNo, pool)
+}
+
+// DrawN draws exactly n winners of the prize regardless of the amount of the prize.
+// Availability rules are the same as Draw.
+// It returns ErrNotEnoughParticipants and draws nothing if less than n participants can win.
+// The amount of the prize is set to n after the draw, so it matches the winners (e.g. for Save and Redraw).
+func (d *Draw) DrawN(prizeNo int, n int) (winners []Participant, err error) {
+	defer func() { d.mutated(err) }()
+	defer func() { d.observeDraw(prizeNo, winners, err) }()
+	defer func(start time.Time) {
+		d.logOp("DrawN", start, err, "prize_no", prizeNo, "winners", len(winners))
+	}(time.Now())
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.ensureInit()
+
+	if d.finalized {
+		return []Participant{}, ErrFinalized
+	}
+
+	if _, ok := d.prizes[prizeNo]; !ok {
+		return []Participant{}, ErrPrizeNo
+	}
+
+	if winners, err = d.drawExactly(prizeNo, n); err != nil {
+		return winners, err
+	}
+
+	prize := d.prizes[prizeNo]
+	prize.Amount = n
+	d.prizes[prizeNo] = prize
+	return winners, nil
+}
+
+// DrawFullOrFail draws the full amount of winners of the prize or nothing.
+// Availability rules are the same as Draw.
+// If less participants than the amount can win, it returns ErrCannotFillPrize and records nothing,
+// e.g. for matched-pair giveaways where a partial draw is worse than none.
+func (d *Draw) DrawFullOrFail(prizeNo int) (winners []Participant, err error) {
+	defer func() { d.mutated(err) }()
+	defer func() { d.observeDraw(prizeNo, winners, err) }()
+	defer func(start time.Time) {
+		d.logOp("DrawFullOrFail", start, err, "prize_no", prizeNo, "winners", len(winners))
+	}(time.Now())
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.ensureInit()
+
+	if d.finalized {
+		return []Participant{}, ErrFinalized
+	}
+
+	if _, ok := d.prizes[prizeNo]; !ok {
+		return []Participant{}, ErrPrizeNo
+	}
+
+	prize := d.resolvePercentAmount(prizeNo)
+	if err := checkDrawable(prize.Amount); err != nil {
+		return []Participant{}, err
+	}
+
+	winners, err = d.drawExactly(prizeNo, prize.Amount)
+	if err == ErrNotEnoughParticipants {
+		return winners, ErrCannotFillPrize
+	}
+	if err != nil {
+		return winners, err
+	}
+
+	d.prizes[prizeNo] = prize
+	return winners, nil
+}
+
+// drawExactly draws exactly n winners of the prize or nothing.
+// It returns ErrNotEnoughParticipants if less than n participants can win.
+// The caller should hold the mutex.
+func (d *Draw) drawExactly(prizeNo int, n int) ([]Participant, error) {
+	if n < 1 {
+		return []Participant{}, ErrPrizeAmount
+	}
+
+	if _, ok := d.winners[prizeNo]; ok {
+		return []Participant{}, ErrWinnersExistBeforeDraw
+	}
+
+	participants := d.availableParticipants(prizeNo)
+	// Tickets are drawn with replacement in raffle mode.
+	if len(participants) == 0 || (!d.raffle && len(participants) < n) {
+		return []Participant{}, ErrNotEnoughParticipants
+	}
+
+	winners, err := d.selectWinners(d.rnd, prizeNo, n, participants)
+	if err != nil {
+		return []Participant{}, err
+	}
+
+	if len(winners) < n {
+		// Weights or validators may exclude participants.
+		return []Participant{}, ErrNotEnoughParticipants
+	}
+
+	d.winners[prizeNo] = copyParticipants(winners)
+	d.indexWinners(prizeNo)
+	d.recordSequence(prizeNo, winners)
+	return winners, nil
+}
+
+// DrawWithSeed draws the prize like Draw, but it uses a random generator with the given seed
+// instead of the draw's random source, which is not advanced.
+// The winners are reproducible with the seed and the available participants
+// (sorted by ID), so a sponsor can pre-commit to a seed and verify the result of the prize, see VerifyDraw.
+func (d *Draw) DrawWithSeed(prizeNo int, seed int64) (winners []Participant, err error) {
+	defer func() { d.mutated(err) }()
+	defer func() { d.observeDraw(prizeNo, winners, err) }()
+	defer func(start time.Time) {
+		d.logOp("DrawWithSeed", start, err, "prize_no", prizeNo, "winners", len(winners))
+	}(time.Now())
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.ensureInit()
+
+	if d.finalized {
+		return []Participant{}, ErrFinalized
+	}
+
+	return d.drawPrizeWith(rand.New(rand.NewSource(seed)), prizeNo, nil)
+}
+
+// RedrawPrize discards the current winners of the prize and draws the full amount of winners again
+// (the resolved amount for percent prizes, see SetPrizePercent).
+// The discarded winners are available again for the new draw
+// and they're recorded in the revoke history with the reason "redraw", see Revoked.
+// It's done in one locked operation so no one can observe the prize without winners.
+func (d *Draw) RedrawPrize(prizeNo int) (winners []Participant, err error) {
+	defer func() { d.mutated(err) }()
+	defer func() { d.observeRedraw(prizeNo, winners, err) }()
+	defer func(start time.Time) {
+		d.logOp("RedrawPrize", start, err, "prize_no", prizeNo, "winners", len(winners))
+	}(time.Now())
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.ensureInit()
+
+	if d.finalized {
+		return []Participant{}, ErrFinalized
+	}
+
+	winners = []Participant{}
+
+	if _, ok := d.prizes[prizeNo]; !ok {
+		return winners, ErrPrizeNo
+	}
+
+	oldWinners, ok := d.winners[prizeNo]
+	delete(d.winners, prizeNo)
+
+	// The percentage is resolved again with the current participants.
+	prize := d.resolvePercentAmount(prizeNo)
+	amount := prize.Amount
+	if err := checkDrawable(amount); err != nil {
+		if ok {
+			d.winners[prizeNo] = oldWinners
+		}
+		return winners, err
+	}
+
+	participants := d.availableParticipants(prizeNo)
+	if len(participants) == 0 {
+		// Keep the original winners.
+		if ok {
+			d.winners[prizeNo] = oldWinners
+		}
+		return winners, ErrNoAvailableParticipants
+	}
+
+	winners, err = d.selectWinners(d.rnd, prizeNo, amount, participants)
+	if err != nil {
+		if ok {
+			d.winners[prizeNo] = oldWinners
+		}
+		return winners, err
+	}
+
+	d.addRevoked(prizeNo, oldWinners, "redraw")
+	d.prizes[prizeNo] = prize
+	d.winners[prizeNo] = copyParticipants(winners)
+	d.indexWinners(prizeNo)
+	d.recordSequence(prizeNo, winners)
+	return winners, nil
+}
+
+// ReplacePrizeDraw is the same as RedrawPrize: it discards the winners of the prize and draws them again.
+func (d *Draw) ReplacePrizeDraw(prizeNo int) ([]Participant, error) {
+	return d.RedrawPrize(prizeNo)
+}
+
+// EliminationDraw draws the prize by eliminating participants one by one
+// until the prize amount of participants remain.
+// It returns the elimination order: eliminated participants first,
+// then the winners with the first ranked winner at the end.
+// Only the winners are recorded.
+func (d *Draw) EliminationDraw(prizeNo int) (sequence []Participant, err error) {
+	var winners []Participant
+
+	defer func() { d.mutated(err) }()
+	defer func() { d.observeDraw(prizeNo, winners, err) }()
+	defer func(start time.Time) {
+		d.logOp("EliminationDraw", start, err, "prize_no", prizeNo, "winners", len(winners))
+	}(time.Now())
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.ensureInit()
+
+	sequence = []Participant{}
+
+	if d.finalized {
+		return sequence, ErrFinalized
+	}
+
+	winners, err = d.drawPrize(prizeNo)
+	if err != nil {
+		return sequence, err
+	}
+
+	// Eliminate the other participants in random order.
+	others := []Participant{}
+	for _, p := range d.availableParticipants(prizeNo) {
+		if !containsParticipant(winners, p.ID) {
+			others = append(others, p)
+		}
+	}
+	sequence = append(sequence, draw(d.rnd, len(others), others)...)
+
+	for i := len(winners) - 1; i >= 0; i-- {
+		sequence = append(sequence, winners[i])
+	}
+
+	return sequence, nil
+}
+
+// FairnessReport runs the draw of the prize for the given iterations
+// without recording any winners and returns how many times each available participant was picked.
+// It's used to check the draw is unbiased (e.g. chi-square test on the counts).
+// It uses its own random source so the draw's random sequence is not affected.
+func (d *Draw) FairnessReport(iterations int, prizeNo int) (map[string]int, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	counts := make(map[string]int)
+
+	if _, ok := d.prizes[prizeNo]; !ok {
+		return counts, ErrPrizeNo
+	}
+
+	amount := d.effectiveAmount(d.prizes[prizeNo])
+	if err := checkDrawable(amount); err != nil {
+		return counts, err
+	}
+
+	participants := d.availableParticipants(prizeNo)
+	if len(participants) == 0 {
+		return counts, ErrNoAvailableParticipants
+	}
+
+	for _, p := range participants {
+		counts[p.ID] = 0
+	}
+
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	for i := 0; i < iterations; i++ {
+		winners, err := d.selectWinners(r, prizeNo, amount, participants)
+		if err != nil {
+			return counts, err
+		}
+		for _, winner := range winners {
+			counts[winner.ID]++
+		}
+	}
+
+	return counts, nil
+}
+
+// Revoke revokes the winners of the given prize.
+// It'll remove revoked winners from winners of the prize
+// and return the remaining winners of the prize.
+// Revoked winners are kept in the revoke history, see Revoked.
+func (d *Draw) Revoke(prizeNo int, revokedWinners []Participant) ([]Participant, error) {
+	return d.RevokeWithReason(prizeNo, revokedWinners, "")
+}
+
+// RevokeWithReason works like Revoke and records the reason in the revoke history.
+func (d *Draw) RevokeWithReason(prizeNo int, revokedWinners []Participant, reason string) (winners []Participant, err error) {
+	defer func() { d.mutated(err) }()
+	defer func() { d.observeRevoke(map[int][]Participant{prizeNo: revokedWinners}, err) }()
+	defer func(start time.Time) {
+		d.logOp("RevokeWithReason", start, err, "prize_no", prizeNo, "revoked", len(revokedWinners))
+	}(time.Now())
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.ensureInit()
+
+	if d.finalized {
+		return []Participant{}, ErrFinalized
+	}
+
+	return d.revoke(prizeNo, revokedWinners, reason)
+}
+
+// revoke removes revoked winners from winners of the prize and records them in the revoke history.
+// It returns the remaining winners of the prize.
+// The caller should hold the mutex.
+func (d *Draw) revoke(prizeNo int, revokedWinners []Participant, reason string) ([]Participant, error) {
+	winners := []Participant{}
+
+	if _, ok := d.prizes[prizeNo]; !ok {
+		return winners, ErrPrizeNo
+	}
+
+	amount := d.prizes[prizeNo].Amount
+	if err := checkDrawable(amount); err != nil {
+		return winners, err
+	}
+
+	if _, ok := d.winners[prizeNo]; !ok {
+		return winners, ErrNoOriginalWinnersBeforeRedraw
+	}
+
+	// Remove original winners for the prize before re-draw.
+	originalWinnerMap := participantSliceToMap(d.winners[prizeNo])
+
+	for _, revokedWinner := range revokedWinners {
+		id := d.normalizeID(revokedWinner.ID)
+		if _, ok := originalWinnerMap[id]; !ok {
+			return winners, ErrRevokedWinnerNotMatch
+		}
+		delete(originalWinnerMap, id)
+	}
+
+	// Keep the remaining winners in draw order.
+	remained := []Participant{}
+	revoked := []Participant{}
+	for _, winner := range d.winners[prizeNo] {
+		if _, ok := originalWinnerMap[winner.ID]; ok {
+			remained = append(remained, winner)
+		} else {
+			revoked = append(revoked, winner)
+		}
+	}
+
+	d.winners[prizeNo] = remained
+	d.indexWinners(prizeNo)
+	d.addRevoked(prizeNo, revoked, reason)
+	return copyParticipants(d.winners[prizeNo]), nil
+}
+
+// RemoveWinner revokes the winner with the given ID of the prize like Revoke.
+// It returns ErrRevokedWinnerNotMatch if the participant is not a winner of the prize.
+func (d *Draw) RemoveWinner(prizeNo int, id string) error {
+	// Revoke matches winners by ID.
+	_, err := d.RevokeWithReason(prizeNo, []Participant{{ID: id}}, "")
+	return err
+}
+
+// addRevoked appends revoked winners of the prize to the revoke history.
+// The caller should hold the mutex.
+func (d *Draw) addRevoked(prizeNo int, revoked []Participant, reason string) {
+	tm := d.now()
+	for _, p := range revoked {
+		d.revoked[prizeNo] = append(d.revoked[prizeNo], RevokedWinner{p, reason, tm})
 	}
+}
 
-	winners = draw(amount, participants)
+// Revoked returns the revoke history of the prize in revoke order.
+// The history is only for audit and it doesn't affect draws.
+func (d *Draw) Revoked(prizeNo int) []RevokedWinner {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
 
-	d.winners[prizeNo] = winners
-	return winners, nil
+	return append([]RevokedWinner{}, d.revoked[prizeNo]...)
 }
 
-// Revoke revokes the winners of the given prize.
-// It'll remove revoked winners from winners of the prize.
-func (d *Draw) Revoke(prizeNo int, revokedWinners []Participant) error {
+// RevokeByID removes the winners with the given IDs from all prizes.
+// It returns the revoked winners grouped by prize no.
+// IDs which didn't win any prize are ignored.
+func (d *Draw) RevokeByID(ids ...string) (revoked map[int][]Participant, err error) {
+	defer func() { d.mutated(err) }()
+	defer func() { d.observeRevoke(revoked, err) }()
+	defer func(start time.Time) {
+		d.logOp("RevokeByID", start, err, "ids", len(ids))
+	}(time.Now())
+
 	d.mutex.Lock()
 	defer d.mutex.Unlock()
 
-	if _, ok := d.prizes[prizeNo]; !ok {
-		return ErrPrizeNo
-	}
+	d.ensureInit()
 
-	amount := d.prizes[prizeNo].Amount
-	if amount < 1 {
-		return ErrPrizeAmount
+	if d.finalized {
+		return map[int][]Participant{}, ErrFinalized
 	}
 
-	if _, ok := d.winners[prizeNo]; !ok {
-		return ErrNoOriginalWinnersBeforeRedraw
+	revoked = make(map[int][]Participant)
+
+	idMap := make(map[string]bool)
+	for _, id := range ids {
+		idMap[d.normalizeID(id)] = true
 	}
 
-	// Remove original winners for the prize before re-draw.
-	originalWinnerMap := participantSliceToMap(d.winners[prizeNo])
+	for prizeNo, winners := range d.winners {
+		remained := []Participant{}
+		for _, winner := range winners {
+			if idMap[winner.ID] {
+				revoked[prizeNo] = append(revoked[prizeNo], winner)
+			} else {
+				remained = append(remained, winner)
+			}
+		}
 
-	for _, revokedWinner := range revokedWinners {
-		if _, ok := originalWinnerMap[revokedWinner.ID]; !ok {
-			return ErrRevokedWinnerNotMatch
+		if len(revoked[prizeNo]) > 0 {
+			d.winners[prizeNo] = remained
+			d.indexWinners(prizeNo)
+			d.addRevoked(prizeNo, revoked[prizeNo], "")
 		}
-		delete(originalWinnerMap, revokedWinner.ID)
 	}
 
-	d.winners[prizeNo] = participantMapToSlice(originalWinnerMap)
-	return nil
+	return revoked, nil
 }
 
-func (d *Draw) Redraw(prizeNo int, amount int) ([]Participant, error) {
+// Redraw draws amount more winners of the prize to fill the slots of revoked winners.
+// New winners are appended to the winners of the prize.
+// If the available participants run out before amount winners are drawn,
+// the drawn winners are still appended and returned with ErrPartialDraw,
+// so the prize is known to be under-filled.
+func (d *Draw) Redraw(prizeNo int, amount int) (winners []Participant, err error) {
+	defer func() { d.mutated(err) }()
+	defer func() { d.observeRedraw(prizeNo, winners, err) }()
+	defer func(start time.Time) {
+		d.logOp("Redraw", start, err, "prize_no", prizeNo, "winners", len(winners))
+	}(time.Now())
+
 	d.mutex.Lock()
 	defer d.mutex.Unlock()
 
+	d.ensureInit()
+
+	if d.finalized {
+		return []Participant{}, ErrFinalized
+	}
+
+	return d.redraw(prizeNo, amount)
+}
+
+// redraw draws amount more winners of the prize and appends them to the winners of the prize.
+// The caller should hold the mutex.
+func (d *Draw) redraw(prizeNo int, amount int) ([]Participant, error) {
 	winners := []Participant{}
 
 	if _, ok := d.prizes[prizeNo]; !ok {
 		return winners, ErrPrizeNo
 	}
 
-	if d.prizes[prizeNo].Amount < 1 {
-		return winners, ErrPrizeAmount
+	if err := checkDrawable(d.prizes[prizeNo].Amount); err != nil {
+		return winners, err
 	}
 
 	if _, ok := d.winners[prizeNo]; !ok {
@@ -390,37 +2653,153 @@ func (d *Draw) Redraw(prizeNo int, amount int) ([]Participant, error) {
 
 	participants := d.availableParticipants(prizeNo)
 	if len(participants) == 0 {
+		d.notePoolExhausted(prizeNo, 0, amount)
 		return winners, ErrNoAvailableParticipants
 	}
 
 	// Get new winners.
-	winners = draw(amount, participants)
+	winners, err := d.selectWinners(d.rnd, prizeNo, amount, participants)
+	if err != nil {
+		return winners, err
+	}
 
 	// Append new winners and original winners.
 	d.winners[prizeNo] = append(d.winners[prizeNo], winners...)
+	d.indexWinners(prizeNo)
+	d.recordSequence(prizeNo, winners)
+
+	if len(winners) < amount {
+		d.notePoolExhausted(prizeNo, len(winners), amount)
+		return winners, ErrPartialDraw
+	}
 	return winners, nil
 }
 
+// WinnerAtRank returns the winner of the prize at the given rank.
+// Winners of a prize are kept in draw order: the first drawn winner is rank 1.
+// Revoke keeps the order of remaining winners and Redraw appends new winners to the end.
+func (d *Draw) WinnerAtRank(prizeNo, rank int) (Participant, bool) {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	winners := d.winners[prizeNo]
+	if rank < 1 || rank > len(winners) {
+		return Participant{}, false
+	}
+
+	return winners[rank-1], true
+}
+
+// AllWinners returns a copy of the winners of all prizes keyed by prize no.
 func (d *Draw) AllWinners() map[int][]Participant {
-	d.mutex.Lock()
-	defer d.mutex.Unlock()
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	winners := make(map[int][]Participant)
+	for prizeNo, s := range d.winners {
+		winners[prizeNo] = copyParticipants(s)
+	}
+	return winners
+}
+
+// WinnerEntry is a winner paired with the prize won.
+type WinnerEntry struct {
+	Prize  Prize       `json:"prize"`
+	Winner Participant `json:"winner"`
+}
+
+// WinnersWithPrize returns all winners paired with their prizes.
+// Entries are sorted by prize no, then by winner ID.
+func (d *Draw) WinnersWithPrize() []WinnerEntry {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	entries := []WinnerEntry{}
+	for prizeNo, winners := range d.winners {
+		for _, winner := range winners {
+			entries = append(entries, WinnerEntry{d.prizes[prizeNo], winner})
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Prize.No != entries[j].Prize.No {
+			return entries[i].Prize.No < entries[j].Prize.No
+		}
+		return entries[i].Winner.ID < entries[j].Winner.ID
+	})
+
+	return entries
+}
+
+// PrizeWinners contains winners of a prize.
+type PrizeWinners struct {
+	PrizeNo int           `json:"prize_no"`
+	Winners []Participant `json:"winners"`
+}
+
+// WinnersResult is the winners of all prizes sorted by prize no.
+// It's encoded as a JSON array instead of a map with stringified prize no keys.
+type WinnersResult []PrizeWinners
+
+// WinnersResult returns the winners of all prizes sorted by prize no.
+// Winners of each prize are in draw order.
+func (d *Draw) WinnersResult() WinnersResult {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
 
-	return d.winners
+	return newWinnersResult(d.winners)
 }
 
-func (d *Draw) ClearWinners(prizeNo int) {
+func newWinnersResult(m map[int][]Participant) WinnersResult {
+	prizeNos := []int{}
+	for prizeNo := range m {
+		prizeNos = append(prizeNos, prizeNo)
+	}
+	sort.Ints(prizeNos)
+
+	result := WinnersResult{}
+	for _, prizeNo := range prizeNos {
+		winners := make([]Participant, len(m[prizeNo]))
+		copy(winners, m[prizeNo])
+		result = append(result, PrizeWinners{prizeNo, winners})
+	}
+
+	return result
+}
+
+func (d *Draw) ClearWinners(prizeNo int) (err error) {
+	defer func() { d.mutated(err) }()
+
 	d.mutex.Lock()
 	defer d.mutex.Unlock()
 
+	d.ensureInit()
+
+	if d.finalized {
+		return ErrFinalized
+	}
+
 	// Clear the winner slice.
 	d.winners[prizeNo] = []Participant{}
+	d.indexWinners(prizeNo)
+	return nil
 }
 
-func (d *Draw) ClearAllWinners() {
+func (d *Draw) ClearAllWinners() (err error) {
+	defer func() { d.mutated(err) }()
+
 	d.mutex.Lock()
 	defer d.mutex.Unlock()
 
+	d.ensureInit()
+
+	if d.finalized {
+		return ErrFinalized
+	}
+
 	d.winners = make(map[int][]Participant)
+	d.reindexWinners()
+	return nil
 }
 
 func makeDataFileName(name string) string {
@@ -428,7 +2807,49 @@ func makeDataFileName(name string) string {
 	return path.Join(AppDataDir, f)
 }
 
-func computeWinnersHash(winners map[int][]Participant) []byte {
+const (
+	// HashMD5 is the default hash algorithm of the checksum.
+	HashMD5 = "md5"
+	// HashSHA256 uses SHA-256 to compute the checksum.
+	HashSHA256 = "sha256"
+)
+
+// newHashFunc returns the hash constructor of the algorithm.
+// Empty algorithm means MD5 for compatibility with old data files.
+func newHashFunc(algorithm string) (func() hash.Hash, error) {
+	switch algorithm {
+	case "", HashMD5:
+		return md5.New, nil
+	case HashSHA256:
+		return sha256.New, nil
+	default:
+		return nil, ErrHashAlgorithm
+	}
+}
+
+// SetHashAlgorithm sets the hash algorithm used to compute the checksum when saving.
+// It's HashMD5 by default. The algorithm is recorded in the saved data
+// so Load can verify the checksum with the same algorithm.
+// It returns ErrFinalized if the draw is finalized.
+func (d *Draw) SetHashAlgorithm(algorithm string) (err error) {
+	defer func() { d.mutated(err) }()
+
+	if _, err := newHashFunc(algorithm); err != nil {
+		return err
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.finalized {
+		return ErrFinalized
+	}
+
+	d.hashAlgorithm = algorithm
+	return nil
+}
+
+func computeWinnersHash(newHash func() hash.Hash, winners map[int][]Participant) []byte {
 	var arr []int
 
 	// Sort winner map by key
@@ -440,7 +2861,7 @@ func computeWinnersHash(winners map[int][]Participant) []byte {
 		return arr[i] < arr[j]
 	})
 
-	h := md5.New()
+	h := newHash()
 	for _, prizeNo := range arr {
 		s := strconv.FormatInt(int64(prizeNo), 10)
 		h.Write([]byte(s))
@@ -453,18 +2874,126 @@ func computeWinnersHash(winners map[int][]Participant) []byte {
 	return h.Sum(nil)
 }
 
-func (d *Draw) Save(w io.Writer) error {
+// computeChecksum computes the checksum of the save data which covers the winners, the metadata,
+// the state of the random source if it's present, the seed commitment, the finalized flag,
+// the multi-win settings, the hash algorithm and the audit log.
+func computeChecksum(newHash func() hash.Hash, data *SaveData) string {
+	h := newHash()
+	h.Write(computeWinnersHash(newHash, data.Winners))
+	writeMetadata(h, data.Metadata)
+	if state := data.RandState; state != nil {
+		fmt.Fprintf(h, "rand_state=%q,%d,%d\n", state.Algorithm, state.Seed, state.Count)
+	}
+	fmt.Fprintf(h, "commitment=%q\n", data.Commitment)
+	fmt.Fprintf(h, "sealed_seed=%q\n", data.SealedSeed)
+	fmt.Fprintf(h, "finalized=%t\n", data.Finalized)
+	fmt.Fprintf(h, "multi_win=%t,%d\n", data.MultiWin, data.MaxWinsPerParticipant)
+	fmt.Fprintf(h, "hash_algorithm=%q\n", data.HashAlgorithm)
+	// Audit entries can always be encoded.
+	h.Write([]byte("audit="))
+	json.NewEncoder(h).Encode(data.Audit)
+
+	return fmt.Sprintf("%X", h.Sum(nil))
+}
+
+// computeLegacyChecksum computes the checksum of data written by older versions,
+// which covers the winners and the metadata only.
+// Without metadata, it's the hex encoded winners hash.
+func computeLegacyChecksum(newHash func() hash.Hash, winners map[int][]Participant, metadata map[string]string) string {
+	if len(metadata) == 0 {
+		return fmt.Sprintf("%X", computeWinnersHash(newHash, winners))
+	}
+
+	h := newHash()
+	h.Write(computeWinnersHash(newHash, winners))
+	writeMetadata(h, metadata)
+	return fmt.Sprintf("%X", h.Sum(nil))
+}
+
+// writeMetadata writes the metadata sorted by keys to the hash.
+func writeMetadata(h hash.Hash, metadata map[string]string) {
+	keys := []string{}
+	for k := range metadata {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		h.Write([]byte(strconv.Quote(k) + "=" + strconv.Quote(metadata[k]) + "\n"))
+	}
+}
+
+// WinnersChecksum returns the hex encoded hash of the current winners (IDs and names by prize no).
+// It's not the checksum written by Save, which also covers the metadata, the state of the random source and other fields.
+func (d *Draw) WinnersChecksum() string {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	newHash, err := newHashFunc(d.hashAlgorithm)
+	if err != nil {
+		// The hash algorithm is checked when it's set or loaded.
+		return ""
+	}
+
+	return fmt.Sprintf("%X", computeWinnersHash(newHash, d.winners))
+}
+
+// Save writes the data of the draw as indented JSON which is easy to read and edit.
+//
+// The output is deterministic for the same data:
+// encoding/json writes map keys (prize numbers, participant IDs) in sorted order,
+// winners of each prize are in draw order and excluded IDs are sorted.
+// Map keys are sorted as strings, so prize numbers are in lexical order (e.g. "10" before "2").
+// Only last_updated differs between saves of the same data,
+// so save files can be diffed and tracked in version control.
+//
+// It refuses to write inconsistent data and returns an error wrapping ErrInconsistentState
+// if winners reference unknown prizes or participants, or a prize has more winners than its amount
+// (e.g. after loading orphan winners by LoadWithReport or lowering the amount of a drawn prize).
+// Then the save validators are run, see AddSaveValidator.
+func (d *Draw) Save(w io.Writer) (err error) {
+	defer func(start time.Time) { d.logOp("Save", start, err) }(time.Now())
+
+	_, err = d.save(w, true)
+	return err
+}
+
+// SaveCompact writes the data of the draw as JSON without indentation.
+// The data is the same as Save but the output is much smaller for events with many participants,
+// because the indentation of each participant is not written.
+// Note that both Save and SaveCompact encode all data in memory before writing it.
+func (d *Draw) SaveCompact(w io.Writer) (err error) {
+	defer func(start time.Time) { d.logOp("SaveCompact", start, err) }(time.Now())
+
+	_, err = d.save(w, false)
+	return err
+}
+
+// save writes the data of the draw and returns the number of mutations of the saved data.
+func (d *Draw) save(w io.Writer, indent bool) (uint64, error) {
 	d.mutex.Lock()
 	defer d.mutex.Unlock()
 
-	tm := time.Now()
+	d.ensureInit()
+
+	if err := d.checkConsistency(); err != nil {
+		return 0, err
+	}
+
+	newHash, err := newHashFunc(d.hashAlgorithm)
+	if err != nil {
+		return 0, err
+	}
+
+	tm := d.now()
 
 	data := SaveData{
-		d.name,
-		d.prizes,
-		d.participants,
-		d.winners,
-		fmt.Sprintf("%04d-%02d-%02d %02d:%02d:%02d",
+		Version:      SaveDataVersion,
+		Name:         d.name,
+		Prizes:       d.prizes,
+		Participants: d.participants,
+		Winners:      d.winners,
+		LastUpdated: fmt.Sprintf("%04d-%02d-%02d %02d:%02d:%02d",
 			tm.Year(),
 			tm.Month(),
 			tm.Day(),
@@ -472,45 +3001,311 @@ func (d *Draw) Save(w io.Writer) error {
 			tm.Minute(),
 			tm.Second(),
 		),
-		fmt.Sprintf("%X", computeWinnersHash(d.winners)),
+		MultiWin:              d.multiWin,
+		MaxWinsPerParticipant: d.maxWinsPerParticipant,
+		RandState:             d.src.state(),
+		Finalized:             d.finalized,
+		HashAlgorithm:         d.hashAlgorithm,
+		Revoked:               d.revoked,
+		Excluded:              d.excludedIDs(),
+		Alternates:            d.alternates,
+		Commitment:            d.commitment,
+		Raffle:                d.raffle,
+		Tickets:               d.tickets,
+		Sequences:             d.sequences,
+		FrozenPools:           d.frozenPools,
+		Metadata:              d.metadata,
+		Audit:                 d.audit,
+	}
+
+	if err := d.sealSeed(&data); err != nil {
+		return 0, err
+	}
+
+	data.Checksum = computeChecksum(newHash, &data)
+
+	for _, v := range d.saveValidators {
+		if err := v(&data); err != nil {
+			return 0, err
+		}
 	}
 
 	enc := json.NewEncoder(w)
-	enc.SetIndent("", "    ")
-	return enc.Encode(&data)
+	if indent {
+		enc.SetIndent("", "    ")
+	}
+	if err := enc.Encode(&data); err != nil {
+		return 0, err
+	}
+
+	return d.mutations, nil
+}
+
+// checkConsistency checks that winners reference existing prizes and participants
+// and no prize has more winners than its amount.
+// It returns an error wrapping ErrInconsistentState for the first problem found.
+// The caller should hold the mutex.
+func (d *Draw) checkConsistency() error {
+	return checkWinners(d.prizes, d.participants, d.winners)
+}
+
+// checkWinners checks that winners reference existing prizes and participants
+// and no prize has more winners than its amount.
+// The amount of a percent prize which is not resolved yet is the percentage of the participants
+// like effectiveAmount, but without the max prize amount which is not saved.
+func checkWinners(prizes map[int]Prize, participants map[string]Participant, allWinners map[int][]Participant) error {
+	prizeNos := []int{}
+	for prizeNo := range allWinners {
+		prizeNos = append(prizeNos, prizeNo)
+	}
+	sort.Ints(prizeNos)
+
+	for _, prizeNo := range prizeNos {
+		winners := allWinners[prizeNo]
+		prize, ok := prizes[prizeNo]
+		if !ok {
+			if len(winners) == 0 {
+				continue
+			}
+			return fmt.Errorf("winners of unknown prize no %d: %w", prizeNo, ErrInconsistentState)
+		}
+
+		amount := prize.Amount
+		if prize.Percent > 0 && amount == 0 {
+			amount = percentAmount(len(participants), prize.Percent, 0)
+		}
+		if len(winners) > amount {
+			return fmt.Errorf("prize no %d has %d winners over its amount %d: %w", prizeNo, len(winners), amount, ErrInconsistentState)
+		}
+
+		for _, winner := range winners {
+			if _, ok := participants[winner.ID]; !ok {
+				return fmt.Errorf("prize no %d: unknown winner id %s: %w", prizeNo, winner.ID, ErrInconsistentState)
+			}
+		}
+	}
+
+	return nil
 }
 
-func (d *Draw) SaveToFile() error {
-	dataFile := makeDataFileName(d.name)
+// SaveToFile saves the data of the draw to the data file in AppDataDir.
+// Concurrent calls are serialized, so the data file always contains the data of the last call.
+// The data is written to a temporary file which replaces the data file,
+// so the data file is never partially written.
+// The draw is marked clean (see IsDirty) only after the data file is replaced successfully.
+func (d *Draw) SaveToFile() (err error) {
+	defer func(start time.Time) { d.logOp("SaveToFile", start, err) }(time.Now())
 
-	f, err := os.Create(dataFile)
+	d.fileMutex.Lock()
+	defer d.fileMutex.Unlock()
+
+	// Save to a buffer first, so the data file is not truncated if Save fails.
+	buf := &bytes.Buffer{}
+	mutations, err := d.save(buf, true)
 	if err != nil {
 		return err
 	}
-	defer f.Close()
 
-	return d.Save(f)
+	if err := writeFileAtomic(makeDataFileName(d.Name()), buf.Bytes()); err != nil {
+		return err
+	}
+
+	// Keep the draw dirty if it's changed after the data was saved.
+	d.mutex.Lock()
+	if d.mutations == mutations {
+		d.dirty = false
+	}
+	d.mutex.Unlock()
+	return nil
+}
+
+// writeFileAtomic writes data to a temporary file in the directory of file and renames it to file.
+func writeFileAtomic(file string, data []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(file), filepath.Base(file)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmp := f.Name()
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+
+	if err := f.Sync(); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+
+	if err := os.Rename(tmp, file); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
 }
 
-func (d *Draw) Load(r io.Reader) error {
+// SetIgnoreNameMismatch sets if Load accepts data whose name is different from the draw's name.
+// It's false by default and Load returns ErrNameMismatch for such data.
+func (d *Draw) SetIgnoreNameMismatch(ignore bool) {
 	d.mutex.Lock()
 	defer d.mutex.Unlock()
 
+	d.ignoreNameMismatch = ignore
+}
+
+// Load loads the data of the draw saved by Save.
+// Gzipped data (e.g. archived save files) is detected and decompressed automatically.
+// It returns ErrNameMismatch if the name in the data is not the name of the draw,
+// see SetIgnoreNameMismatch.
+// It returns ErrUnknownWinner if a winner in the data is not a participant,
+// use LoadWithReport to accept such data.
+// Prize amounts and the numbers of prizes and participants are checked against the limits of the draw
+// like ApplySaveData.
+// It returns ErrFinalized if the draw is finalized.
+func (d *Draw) Load(r io.Reader) (err error) {
+	defer func(start time.Time) { d.logOp("Load", start, err) }(time.Now())
+
+	_, err = d.load(r, false)
+	return err
+}
+
+// LoadReport reports inconsistent data found by LoadWithReport.
+type LoadReport struct {
+	// OrphanWinners are winners who are not participants.
+	// Entries are sorted by prize no, then by winner ID.
+	OrphanWinners []WinnerEntry `json:"orphan_winners"`
+}
+
+// LoadWithReport loads the data like Load, but it accepts winners who are not participants
+// and lists them in the report, so the caller can decide what to do (e.g. remove them).
+func (d *Draw) LoadWithReport(r io.Reader) (report LoadReport, err error) {
+	defer func(start time.Time) {
+		d.logOp("LoadWithReport", start, err, "orphan_winners", len(report.OrphanWinners))
+	}(time.Now())
+
+	return d.load(r, true)
+}
+
+// load decodes, verifies and replays the data before the lock is taken,
+// so the draw is locked only to replace its state.
+func (d *Draw) load(r io.Reader, lenient bool) (LoadReport, error) {
+	report := LoadReport{OrphanWinners: []WinnerEntry{}}
+
+	d.mutex.RLock()
+	finalized, legacy, key, normalize := d.finalized, d.acceptLegacyChecksum, d.seedKey, d.idNormalizer
+	d.mutex.RUnlock()
+
+	if finalized {
+		return report, ErrFinalized
+	}
+
 	data := SaveData{}
-	dec := json.NewDecoder(r)
+	if err := decodeSaveData(r, &data); err != nil {
+		return report, err
+	}
 
-	if err := dec.Decode(&data); err != nil {
-		return err
+	if data.Version > SaveDataVersion {
+		return report, ErrSaveDataVersion
+	}
+
+	if err := verifyChecksum(&data, legacy); err != nil {
+		return report, err
+	}
+
+	if err := unsealSeed(&data, key); err != nil {
+		return report, err
+	}
+
+	// The checksum covers the IDs in the file, so they're normalized after it's verified.
+	if err := normalizeSaveData(&data, normalize); err != nil {
+		return report, err
+	}
+
+	src, err := restoreRandSource(data.RandState)
+	if err != nil {
+		return report, err
 	}
 
-	checksum := computeWinnersHash(data.Winners)
-	if fmt.Sprintf("%X", checksum) != data.Checksum {
-		return ErrChecksum
+	// Check if all winners are participants.
+	for prizeNo, winners := range data.Winners {
+		for _, winner := range winners {
+			if _, ok := data.Participants[winner.ID]; ok {
+				continue
+			}
+			if !lenient {
+				return report, fmt.Errorf("prize no %d: id %s: %w", prizeNo, winner.ID, ErrUnknownWinner)
+			}
+			report.OrphanWinners = append(report.OrphanWinners, WinnerEntry{data.Prizes[prizeNo], winner})
+		}
+	}
+
+	sort.Slice(report.OrphanWinners, func(i, j int) bool {
+		a, b := report.OrphanWinners[i], report.OrphanWinners[j]
+		if a.Prize.No != b.Prize.No {
+			return a.Prize.No < b.Prize.No
+		}
+		return a.Winner.ID < b.Winner.ID
+	})
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	// The draw may be finalized while the data is verified.
+	if d.finalized {
+		return report, ErrFinalized
+	}
+
+	// Make sure the data belongs to the draw.
+	if data.Name != d.name && !d.ignoreNameMismatch {
+		return report, ErrNameMismatch
 	}
 
+	if err := d.checkSaveDataLimits(&data); err != nil {
+		return report, err
+	}
+
+	d.restore(&data, src)
+	return report, nil
+}
+
+// restore replaces the state of the draw with the data and the random source restored from it.
+// The random source is not replaced if src is nil.
+// The caller should hold the mutex.
+func (d *Draw) restore(data *SaveData, src *randSource) {
+	d.dirty = false
 	d.prizes = data.Prizes
 	d.participants = data.Participants
 	d.winners = data.Winners
+	d.multiWin = data.MultiWin
+	d.maxWinsPerParticipant = data.MaxWinsPerParticipant
+	d.finalized = data.Finalized
+	d.hashAlgorithm = data.HashAlgorithm
+	d.revoked = data.Revoked
+	d.alternates = data.Alternates
+	d.commitment = data.Commitment
+	d.raffle = data.Raffle
+	d.tickets = data.Tickets
+	d.sequences = data.Sequences
+	d.frozenPools = data.FrozenPools
+	d.metadata = data.Metadata
+	d.audit = data.Audit
+	d.excluded = make(map[string]bool)
+	for _, id := range data.Excluded {
+		d.excluded[id] = true
+	}
+
+	// Restore the random source to continue the random sequence.
+	if src != nil {
+		d.src = src
+		d.rnd = rand.New(d.src)
+	}
 
 	// Check if map is nil
 	if d.prizes == nil {
@@ -525,11 +3320,111 @@ func (d *Draw) Load(r io.Reader) error {
 		d.winners = make(map[int][]Participant)
 	}
 
-	return nil
+	if d.revoked == nil {
+		d.revoked = make(map[int][]RevokedWinner)
+	}
+
+	if d.alternates == nil {
+		d.alternates = make(map[int][]Participant)
+	}
+
+	if d.tickets == nil {
+		d.tickets = make(map[string]int)
+	}
+
+	if d.sequences == nil {
+		d.sequences = make(map[int][]string)
+	}
+
+	if d.frozenPools == nil {
+		d.frozenPools = make(map[int][]string)
+	}
+
+	d.invalidatePool()
+	d.reindexWinners()
+	d.publishWinners()
+}
+
+// verifyChecksum returns ErrChecksum if the checksum of the data is incorrect.
+// If legacy is true, the checksum of older versions which covers the winners and the metadata only
+// is accepted too, see SetAcceptLegacyChecksum.
+func verifyChecksum(data *SaveData, legacy bool) error {
+	newHash, err := newHashFunc(data.HashAlgorithm)
+	if err != nil {
+		return err
+	}
+
+	if computeChecksum(newHash, data) == data.Checksum {
+		return nil
+	}
+
+	if (legacy || isVersion1Data(data)) && computeLegacyChecksum(newHash, data.Winners, data.Metadata) == data.Checksum {
+		return nil
+	}
+	return ErrChecksum
+}
+
+// isVersion1Data reports if the data has only the fields of version 1 (e.g. written by the first release),
+// whose checksum covers the winners only, so it's accepted without SetAcceptLegacyChecksum.
+// The version is not trusted: data with any field of later versions (e.g. the state of the random source)
+// must have the checksum of its version.
+func isVersion1Data(data *SaveData) bool {
+	return data.Version <= 1 &&
+		!data.MultiWin &&
+		data.MaxWinsPerParticipant == 0 &&
+		data.RandState == nil &&
+		!data.Finalized &&
+		data.HashAlgorithm == "" &&
+		len(data.Revoked) == 0 &&
+		len(data.Excluded) == 0 &&
+		len(data.Alternates) == 0 &&
+		data.Commitment == "" &&
+		!data.Raffle &&
+		len(data.Tickets) == 0 &&
+		len(data.Sequences) == 0 &&
+		len(data.Metadata) == 0 &&
+		len(data.FrozenPools) == 0 &&
+		len(data.Audit) == 0 &&
+		data.SealedSeed == ""
+}
+
+// SetAcceptLegacyChecksum sets if Load, LoadWithReport and ApplySaveData accept data
+// written by older versions whose checksum covers the winners and the metadata only.
+// Other fields of such data (e.g. the state of the random source) can be tampered without notice,
+// so it's false by default and should only be used to migrate old data files: load and save them again.
+// Data of version 1 without fields of later versions (e.g. written by the first release) is always accepted,
+// because its checksum covers everything the checksum of version 1 did.
+func (d *Draw) SetAcceptLegacyChecksum(accept bool) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.acceptLegacyChecksum = accept
+}
+
+// VerifyReader verifies the checksum of the data written by Save without loading it to a draw.
+// It returns ErrChecksum if the data is tampered.
+func VerifyReader(r io.Reader) error {
+	data := SaveData{}
+	if err := decodeSaveData(r, &data); err != nil {
+		return err
+	}
+
+	return verifyChecksum(&data, false)
+}
+
+// VerifyFile verifies the checksum of the data file without loading it to a draw.
+func VerifyFile(file string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return VerifyReader(f)
 }
 
 func (d *Draw) LoadFromFile() error {
-	dataFile := makeDataFileName(d.name)
+	dataFile := makeDataFileName(d.Name())
 
 	f, err := os.Open(dataFile)
 	if err != nil {
@@ -541,10 +3436,20 @@ func (d *Draw) LoadFromFile() error {
 }
 
 func (d *Draw) DataFileExists() bool {
-	dataFile := makeDataFileName(d.name)
+	dataFile := makeDataFileName(d.Name())
 
 	if _, err := os.Stat(dataFile); os.IsNotExist(err) {
 		return false
 	}
 	return true
 }
+
+// LoadOrInit loads the draw from the data file if it exists.
+// If the data file doesn't exist, the draw is kept as a fresh one and nil is returned.
+func (d *Draw) LoadOrInit() error {
+	if !d.DataFileExists() {
+		return nil
+	}
+
+	return d.LoadFromFile()
+}
@@ -0,0 +1,154 @@
+package luckydraw
+
+import (
+	"fmt"
+)
+
+// MergeOptions controls how Merge combines another draw.
+type MergeOptions struct {
+	// Overwrite replaces existing participants, prizes or winners on conflicts.
+	// If it's false, Merge returns an error on the first conflict and nothing is merged.
+	Overwrite bool
+	// Prizes merges prizes of the other draw too.
+	Prizes bool
+	// Winners merges winners of the other draw too.
+	// It's off by default to avoid awarding the same participant twice.
+	Winners bool
+}
+
+// Merge imports participants of the other draw.
+// Prizes and winners are imported only if they're enabled in opts.
+// The merged participants are checked like AddParticipant: their IDs are normalized by the ID normalizer
+// and validated by the participant validator of the draw, and it returns ErrParticipantsLocked
+// if any winners exist (see SetUnlockParticipants) and ErrRegistrationClosed after the registration deadline.
+// The merged participants and prizes are checked against the limits of the draw, see SetMaxParticipants.
+func (d *Draw) Merge(other *Draw, opts MergeOptions) (err error) {
+	if other == d {
+		return nil
+	}
+
+	// Copy the data of the other draw before locking d to avoid deadlock.
+	other.mutex.Lock()
+	participants := copyParticipantMap(other.participants)
+	prizes := make(map[int]Prize)
+	for no, prize := range other.prizes {
+		prizes[no] = prize
+	}
+	winners := make(map[int][]Participant)
+	for no, s := range other.winners {
+		winners[no] = append([]Participant{}, s...)
+	}
+	other.mutex.Unlock()
+
+	defer func() { d.mutated(err) }()
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.ensureInit()
+
+	if d.finalized {
+		return ErrFinalized
+	}
+
+	if len(participants) > 0 {
+		if d.participantsLocked() {
+			return ErrParticipantsLocked
+		}
+
+		if d.registrationClosed() {
+			return ErrRegistrationClosed
+		}
+	}
+
+	// IDs of the other draw may be normalized differently.
+	normalized := make(map[string]Participant, len(participants))
+	for _, p := range participants {
+		p.ID = d.normalizeID(p.ID)
+		if _, ok := normalized[p.ID]; ok {
+			return fmt.Errorf("id %s: %w", p.ID, ErrParticipantExists)
+		}
+		if err := d.validateParticipant(p); err != nil {
+			return fmt.Errorf("id %s: %w", p.ID, err)
+		}
+		normalized[p.ID] = p
+	}
+	participants = normalized
+
+	for no, s := range winners {
+		for i := range s {
+			s[i].ID = d.normalizeID(s[i].ID)
+		}
+		winners[no] = s
+	}
+
+	// Check conflicts first so nothing is merged on error.
+	if !opts.Overwrite {
+		for id := range participants {
+			if _, ok := d.participants[id]; ok {
+				return ErrParticipantExists
+			}
+		}
+
+		if opts.Prizes {
+			for no := range prizes {
+				if _, ok := d.prizes[no]; ok {
+					return ErrPrizeExists
+				}
+			}
+		}
+
+		if opts.Winners {
+			for no := range winners {
+				if _, ok := d.winners[no]; ok {
+					return ErrWinnersExist
+				}
+			}
+		}
+	}
+
+	// Check the limits of the draw with the merged participants and prizes.
+	n := len(d.participants)
+	for id := range participants {
+		if _, ok := d.participants[id]; !ok {
+			n++
+		}
+	}
+	if err := d.checkParticipantsLimit(n); err != nil {
+		return err
+	}
+
+	if opts.Prizes {
+		n := len(d.prizes)
+		for no, prize := range prizes {
+			if err := d.checkPrizeAmount(prize.Amount); err != nil {
+				return fmt.Errorf("prize no %d: %w", no, err)
+			}
+			if _, ok := d.prizes[no]; !ok {
+				n++
+			}
+		}
+		if err := d.checkPrizesLimit(n); err != nil {
+			return err
+		}
+	}
+
+	for id, p := range participants {
+		d.participants[id] = p
+	}
+
+	if opts.Prizes {
+		for no, prize := range prizes {
+			d.prizes[no] = prize
+		}
+	}
+
+	if opts.Winners {
+		for no, s := range winners {
+			d.winners[no] = s
+			d.indexWinners(no)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package luckydraw
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newMergeSource creates a draw with the participants of the IDs.
+func newMergeSource(t *testing.T, ids ...string) *Draw {
+	t.Helper()
+
+	d := New("other")
+	for _, id := range ids {
+		if err := d.AddParticipant(Participant{ID: id, Name: "name " + id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return d
+}
+
+func TestMerge(t *testing.T) {
+	d := newSeededDraw(t, 1)
+
+	if err := d.Merge(newMergeSource(t, "a", "b"), MergeOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(d.Participants()); n != 52 {
+		t.Errorf("got %d participants, want 52", n)
+	}
+
+	if err := d.Merge(newMergeSource(t, "c", "a"), MergeOptions{}); err != ErrParticipantExists {
+		t.Errorf("conflict: got %v, want ErrParticipantExists", err)
+	}
+	if _, ok := d.Participant("c"); ok {
+		t.Errorf("participant c merged on conflict")
+	}
+
+	if err := d.Merge(newMergeSource(t, "c", "a"), MergeOptions{Overwrite: true}); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(d.Participants()); n != 53 {
+		t.Errorf("got %d participants, want 53", n)
+	}
+}
+
+func TestMergeParticipantsLocked(t *testing.T) {
+	d := newSeededDraw(t, 1)
+	if _, err := d.Draw(1); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := d.Merge(newMergeSource(t, "a"), MergeOptions{}); err != ErrParticipantsLocked {
+		t.Errorf("got %v, want ErrParticipantsLocked", err)
+	}
+
+	d.SetUnlockParticipants(true)
+	if err := d.Merge(newMergeSource(t, "a"), MergeOptions{}); err != nil {
+		t.Errorf("unlocked: got %v", err)
+	}
+}
+
+func TestMergeRegistrationClosed(t *testing.T) {
+	d := newSeededDraw(t, 1)
+	d.SetRegistrationDeadline(time.Now().Add(-time.Hour))
+
+	if err := d.Merge(newMergeSource(t, "a"), MergeOptions{}); err != ErrRegistrationClosed {
+		t.Errorf("got %v, want ErrRegistrationClosed", err)
+	}
+}
+
+func TestMergeNormalizesAndValidates(t *testing.T) {
+	d := newSeededDraw(t, 1)
+	d.SetIDNormalizer(strings.ToLower)
+
+	if err := d.Merge(newMergeSource(t, "A"), MergeOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := d.Participant("a"); !ok {
+		t.Errorf("merged ID is not normalized")
+	}
+
+	// Both IDs are normalized to b.
+	if err := d.Merge(newMergeSource(t, "B", "b"), MergeOptions{}); !errors.Is(err, ErrParticipantExists) {
+		t.Errorf("got %v, want ErrParticipantExists", err)
+	}
+
+	errInvalid := fmt.Errorf("invalid id")
+	d.SetParticipantValidator(func(p Participant) error {
+		if p.ID == "x" {
+			return errInvalid
+		}
+		return nil
+	})
+	if err := d.Merge(newMergeSource(t, "w", "x"), MergeOptions{}); !errors.Is(err, errInvalid) {
+		t.Errorf("got %v, want the validator error", err)
+	}
+	if _, ok := d.Participant("w"); ok {
+		t.Errorf("participant w merged on error")
+	}
+}
@@ -0,0 +1,139 @@
+package luckydraw
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// WinnersMerkleRoot returns the hex encoded root of the Merkle tree of winners,
+// so the result can be posted publicly and each winner can prove the inclusion with MerkleProof
+// without the whole list of winners.
+// Leaves are SHA-256 hashes of the prize no and the ID of each winner,
+// ordered by prize no and the draw order of winners.
+// Each parent is the SHA-256 hash of its 2 children in ascending byte order,
+// and the last node of an odd level is promoted to the next level.
+// It returns an empty string if there's no winner.
+func (d *Draw) WinnersMerkleRoot() string {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	leaves := d.merkleLeaves()
+	if len(leaves) == 0 {
+		return ""
+	}
+
+	levels := merkleLevels(leaves)
+	return fmt.Sprintf("%X", levels[len(levels)-1][0])
+}
+
+// MerkleProof returns the inclusion proof of the winner of the prize: the sibling hashes from the leaf to the root.
+// It can be verified against WinnersMerkleRoot by VerifyMerkleProof.
+// It returns ErrNotWinner if the participant is not a winner of the prize.
+func (d *Draw) MerkleProof(prizeNo int, id string) ([][]byte, error) {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	id = d.normalizeID(id)
+	if !containsParticipant(d.winners[prizeNo], id) {
+		return nil, ErrNotWinner
+	}
+
+	leaves := d.merkleLeaves()
+	leaf := merkleLeaf(prizeNo, id)
+	index := 0
+	for i, l := range leaves {
+		if bytes.Equal(l, leaf) {
+			index = i
+			break
+		}
+	}
+
+	proof := [][]byte{}
+	levels := merkleLevels(leaves)
+	for _, level := range levels[:len(levels)-1] {
+		sibling := index ^ 1
+		if sibling < len(level) {
+			proof = append(proof, level[sibling])
+		}
+		index /= 2
+	}
+
+	return proof, nil
+}
+
+// VerifyMerkleProof verifies the inclusion proof of the winner of the prize
+// generated by MerkleProof against the root returned by WinnersMerkleRoot.
+func VerifyMerkleProof(root string, prizeNo int, id string, proof [][]byte) bool {
+	want, err := hex.DecodeString(root)
+	if err != nil {
+		return false
+	}
+
+	h := merkleLeaf(prizeNo, id)
+	for _, sibling := range proof {
+		h = merkleParent(h, sibling)
+	}
+
+	return bytes.Equal(h, want)
+}
+
+// merkleLeaves returns the leaves of the Merkle tree of winners.
+// The caller should hold the mutex.
+func (d *Draw) merkleLeaves() [][]byte {
+	prizeNos := []int{}
+	for prizeNo := range d.winners {
+		prizeNos = append(prizeNos, prizeNo)
+	}
+	sort.Ints(prizeNos)
+
+	leaves := [][]byte{}
+	for _, prizeNo := range prizeNos {
+		for _, winner := range d.winners[prizeNo] {
+			leaves = append(leaves, merkleLeaf(prizeNo, winner.ID))
+		}
+	}
+	return leaves
+}
+
+// merkleLevels returns all levels of the Merkle tree from the leaves to the root.
+func merkleLevels(leaves [][]byte) [][][]byte {
+	levels := [][][]byte{leaves}
+	for level := leaves; len(level) > 1; {
+		next := [][]byte{}
+		for i := 0; i < len(level); i += 2 {
+			if i+1 < len(level) {
+				next = append(next, merkleParent(level[i], level[i+1]))
+			} else {
+				next = append(next, level[i])
+			}
+		}
+		levels = append(levels, next)
+		level = next
+	}
+	return levels
+}
+
+// merkleLeaf hashes the prize no and the ID with a 0x00 prefix
+// so a leaf can't be taken for a parent.
+func merkleLeaf(prizeNo int, id string) []byte {
+	sum := sha256.Sum256([]byte("\x00" + strconv.Itoa(prizeNo) + "\n" + id))
+	return sum[:]
+}
+
+// merkleParent hashes the children in ascending byte order with a 0x01 prefix,
+// so a proof doesn't need the positions of siblings.
+func merkleParent(a, b []byte) []byte {
+	if bytes.Compare(a, b) > 0 {
+		a, b = b, a
+	}
+
+	h := sha256.New()
+	h.Write([]byte{1})
+	h.Write(a)
+	h.Write(b)
+	return h.Sum(nil)
+}
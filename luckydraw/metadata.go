@@ -0,0 +1,35 @@
+package luckydraw
+
+// SetMetadata replaces the event metadata (e.g. venue, date, organizer, notes).
+// The metadata is saved with the draw and covered by the checksum.
+func (d *Draw) SetMetadata(metadata map[string]string) (err error) {
+	defer func() { d.mutated(err) }()
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.ensureInit()
+
+	if d.finalized {
+		return ErrFinalized
+	}
+
+	d.metadata = copyMetadata(metadata)
+	return nil
+}
+
+// Metadata returns a copy of the event metadata.
+func (d *Draw) Metadata() map[string]string {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	return copyMetadata(d.metadata)
+}
+
+func copyMetadata(m map[string]string) map[string]string {
+	copied := make(map[string]string)
+	for k, v := range m {
+		copied[k] = v
+	}
+	return copied
+}
@@ -0,0 +1,72 @@
+package luckydraw
+
+import (
+	"errors"
+)
+
+// Metrics receives operation metrics of a draw (e.g. to export them as Prometheus metrics).
+// Methods are called after the mutex is released, so they can call methods of the draw.
+type Metrics interface {
+	// Drawn is called after winners of a prize are drawn.
+	Drawn(prizeNo, winners int)
+	// Redrawn is called after winners of a prize are redrawn.
+	Redrawn(prizeNo, winners int)
+	// Revoked is called after winners of a prize are revoked.
+	Revoked(prizeNo, revoked int)
+	// Updated is called after each successful mutation with the current numbers
+	// of participants, prizes and remaining slots of all prizes.
+	Updated(participants, prizes, remainingSlots int)
+}
+
+// SetMetrics sets the metrics hook of the draw. nil (default) means no metrics.
+func (d *Draw) SetMetrics(m Metrics) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.metrics = m
+}
+
+func (d *Draw) getMetrics() Metrics {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	return d.metrics
+}
+
+// observeDraw reports drawn winners of the prize to the metrics hook.
+// It should be called after the mutex is released.
+func (d *Draw) observeDraw(prizeNo int, winners []Participant, err error) {
+	if m := d.getMetrics(); m != nil && err == nil && len(winners) > 0 {
+		m.Drawn(prizeNo, len(winners))
+	}
+}
+
+// observeRedraw reports redrawn winners of the prize to the metrics hook.
+// It should be called after the mutex is released.
+func (d *Draw) observeRedraw(prizeNo int, winners []Participant, err error) {
+	if m := d.getMetrics(); m != nil && (err == nil || errors.Is(err, ErrPartialDraw)) {
+		m.Redrawn(prizeNo, len(winners))
+	}
+}
+
+// observeRevoke reports revoked winners to the metrics hook.
+// It should be called after the mutex is released.
+func (d *Draw) observeRevoke(revoked map[int][]Participant, err error) {
+	if m := d.getMetrics(); m != nil && err == nil {
+		for prizeNo, winners := range revoked {
+			m.Revoked(prizeNo, len(winners))
+		}
+	}
+}
+
+// observeUpdate reports the current numbers of the draw to the metrics hook.
+// It should be called after the mutex is released.
+func (d *Draw) observeUpdate() {
+	m := d.getMetrics()
+	if m == nil {
+		return
+	}
+
+	stats := d.Stats()
+	m.Updated(stats.Participants, stats.Prizes, stats.RemainingSlots)
+}
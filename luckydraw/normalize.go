@@ -0,0 +1,121 @@
+package luckydraw
+
+import (
+	"fmt"
+)
+
+// IDNormalizer normalizes a participant ID (e.g. trims leading zeros or lower cases it),
+// so IDs from different sources can be matched.
+type IDNormalizer func(id string) string
+
+// SetIDNormalizer sets the ID normalizer of the draw.
+// IDs are normalized when participants are loaded or added (CSV loaders, SetParticipants, AddParticipant, Merge)
+// and when participants are looked up by ID (e.g. Participant, RemoveParticipant, IsWinner, Revoke).
+// IDs of data loaded by Load, LoadWithReport and ApplySaveData are normalized too.
+// Set it before loading participants, because existing IDs are not normalized again.
+// f is called with the mutex held, so it must not call methods of the draw.
+// nil (default) means IDs are used as they are.
+func (d *Draw) SetIDNormalizer(f IDNormalizer) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.idNormalizer = f
+}
+
+// normalizeID normalizes the ID with the ID normalizer.
+// The caller should hold the mutex.
+func (d *Draw) normalizeID(id string) string {
+	if d.idNormalizer == nil {
+		return id
+	}
+	return d.idNormalizer(id)
+}
+
+// normalizeSaveData normalizes the IDs of participants, winners, revoked winners, excluded participants,
+// alternates, tickets, sequences and frozen pools of the data with f,
+// so IDs of a loaded file match IDs normalized by the loaders.
+// The maps and slices of data are replaced, not modified.
+// It returns ErrParticipantExists if IDs of two participants are normalized to the same ID.
+func normalizeSaveData(data *SaveData, f IDNormalizer) error {
+	if f == nil {
+		return nil
+	}
+
+	participants := make(map[string]Participant, len(data.Participants))
+	for id, p := range data.Participants {
+		p.ID = f(id)
+		if _, ok := participants[p.ID]; ok {
+			return fmt.Errorf("id %s: %w", p.ID, ErrParticipantExists)
+		}
+		participants[p.ID] = p
+	}
+	data.Participants = participants
+
+	data.Winners = normalizeParticipantsByPrize(data.Winners, f)
+	data.Alternates = normalizeParticipantsByPrize(data.Alternates, f)
+	data.Sequences = normalizeIDsByPrize(data.Sequences, f)
+	data.FrozenPools = normalizeIDsByPrize(data.FrozenPools, f)
+
+	if data.Revoked != nil {
+		revoked := make(map[int][]RevokedWinner, len(data.Revoked))
+		for prizeNo, s := range data.Revoked {
+			revoked[prizeNo] = make([]RevokedWinner, len(s))
+			for i, r := range s {
+				r.ID = f(r.ID)
+				revoked[prizeNo][i] = r
+			}
+		}
+		data.Revoked = revoked
+	}
+
+	if data.Excluded != nil {
+		excluded := make([]string, len(data.Excluded))
+		for i, id := range data.Excluded {
+			excluded[i] = f(id)
+		}
+		data.Excluded = excluded
+	}
+
+	if data.Tickets != nil {
+		tickets := make(map[string]int, len(data.Tickets))
+		for id, n := range data.Tickets {
+			tickets[f(id)] += n
+		}
+		data.Tickets = tickets
+	}
+
+	return nil
+}
+
+// normalizeParticipantsByPrize returns a copy of m with the IDs of participants normalized by f.
+func normalizeParticipantsByPrize(m map[int][]Participant, f IDNormalizer) map[int][]Participant {
+	if m == nil {
+		return nil
+	}
+
+	normalized := make(map[int][]Participant, len(m))
+	for prizeNo, s := range m {
+		normalized[prizeNo] = make([]Participant, len(s))
+		for i, p := range s {
+			p.ID = f(p.ID)
+			normalized[prizeNo][i] = p
+		}
+	}
+	return normalized
+}
+
+// normalizeIDsByPrize returns a copy of m with the IDs normalized by f.
+func normalizeIDsByPrize(m map[int][]string, f IDNormalizer) map[int][]string {
+	if m == nil {
+		return nil
+	}
+
+	normalized := make(map[int][]string, len(m))
+	for prizeNo, ids := range m {
+		normalized[prizeNo] = make([]string, len(ids))
+		for i, id := range ids {
+			normalized[prizeNo][i] = f(id)
+		}
+	}
+	return normalized
+}
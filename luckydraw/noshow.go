@@ -0,0 +1,158 @@
+package luckydraw
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RevokeNoShowsCSV revokes winners who didn't claim their prizes from CSV.
+// The first row is the header and each row contains: prize no, id.
+// Revoked winners are recorded in the revoke history with the reason "no-show".
+//
+// If redraw is true, the freed slots of each prize are drawn again like Redraw,
+// but the no-shows of the CSV can't win again.
+// It returns the new winners keyed by prize no.
+//
+// Unmatched rows (e.g. the participant is not a winner of the prize) don't abort the batch:
+// the other rows are still revoked and the returned error joins the errors of all unmatched rows,
+// see errors.Join. Prizes which can't be fully redrawn are reported with ErrPartialDraw.
+func (d *Draw) RevokeNoShowsCSV(r io.Reader, redraw bool) (newWinners map[int][]Participant, err error) {
+	changed := false
+	revoked := make(map[int][]Participant)
+
+	defer func() {
+		if !changed {
+			return
+		}
+		d.observeRevoke(revoked, nil)
+		for prizeNo, winners := range newWinners {
+			d.observeRedraw(prizeNo, winners, nil)
+		}
+		d.mutated(nil)
+	}()
+	defer func(start time.Time) {
+		d.logOp("RevokeNoShowsCSV", start, err, "revoked", len(revoked), "redraw", redraw)
+	}(time.Now())
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.ensureInit()
+
+	newWinners = make(map[int][]Participant)
+
+	if d.finalized {
+		return newWinners, ErrFinalized
+	}
+
+	reader := csv.NewReader(skipBOM(r))
+	reader.FieldsPerRecord = -1
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return newWinners, err
+	}
+
+	errs := []error{}
+	noShows := make(map[int]map[string]bool)
+	ids := make(map[string]bool)
+	for i := 1; i < len(rows); i++ {
+		row := rows[i]
+		if len(row) < 2 {
+			errs = append(errs, fmt.Errorf("row %d: %w", i+1, ErrNoShowsCSV))
+			continue
+		}
+
+		no, err := strconv.Atoi(strings.Trim(row[0], " "))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("row %d: %w", i+1, err))
+			continue
+		}
+
+		id := d.normalizeID(strings.Trim(row[1], " "))
+		if !containsParticipant(d.winners[no], id) || noShows[no][id] {
+			errs = append(errs, fmt.Errorf("row %d: prize no %d: id %s: %w", i+1, no, id, ErrRevokedWinnerNotMatch))
+			continue
+		}
+
+		if noShows[no] == nil {
+			noShows[no] = make(map[string]bool)
+		}
+		noShows[no][id] = true
+		ids[id] = true
+	}
+
+	prizeNos := []int{}
+	for no := range noShows {
+		prizeNos = append(prizeNos, no)
+	}
+	sort.Ints(prizeNos)
+
+	// Keep the remaining winners in draw order.
+	for _, no := range prizeNos {
+		remained := []Participant{}
+		for _, winner := range d.winners[no] {
+			if noShows[no][winner.ID] {
+				revoked[no] = append(revoked[no], winner)
+			} else {
+				remained = append(remained, winner)
+			}
+		}
+
+		d.winners[no] = remained
+		d.indexWinners(no)
+		d.addRevoked(no, revoked[no], "no-show")
+		changed = true
+	}
+
+	if !redraw {
+		return newWinners, errors.Join(errs...)
+	}
+
+	for _, no := range prizeNos {
+		amount := d.prizes[no].Amount - len(d.winners[no])
+		if amount <= 0 {
+			continue
+		}
+
+		participants := []Participant{}
+		for _, p := range d.availableParticipants(no) {
+			if !ids[p.ID] {
+				participants = append(participants, p)
+			}
+		}
+
+		winners, err := d.selectWinners(d.rnd, no, amount, participants)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("prize no %d: %w", no, err))
+			continue
+		}
+
+		d.winners[no] = append(d.winners[no], winners...)
+		d.indexWinners(no)
+		d.recordSequence(no, winners)
+		newWinners[no] = winners
+
+		if len(winners) < amount {
+			errs = append(errs, fmt.Errorf("prize no %d: %w", no, ErrPartialDraw))
+		}
+	}
+
+	return newWinners, errors.Join(errs...)
+}
+
+func (d *Draw) RevokeNoShowsCSVFile(file string, redraw bool) (map[int][]Participant, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return map[int][]Participant{}, err
+	}
+	defer f.Close()
+
+	return d.RevokeNoShowsCSV(f, redraw)
+}
@@ -0,0 +1,86 @@
+package luckydraw
+
+import (
+	"sort"
+	"time"
+)
+
+// DrawByOrder "draws" the prize without randomness for rule-based awards (e.g. the first registrants):
+// available participants are sorted by less and the first amount of them are the winners.
+// Participants which are equal by less are ordered by ID.
+// Availability rules and unique groups are the same as Draw, but weights and the validator are not used.
+// Winners are recorded like Draw.
+func (d *Draw) DrawByOrder(prizeNo int, less func(a, b Participant) bool) (winners []Participant, err error) {
+	defer func() { d.mutated(err) }()
+	defer func() { d.observeDraw(prizeNo, winners, err) }()
+	defer func(start time.Time) {
+		d.logOp("DrawByOrder", start, err, "prize_no", prizeNo, "winners", len(winners))
+	}(time.Now())
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.ensureInit()
+
+	if d.finalized {
+		return []Participant{}, ErrFinalized
+	}
+
+	if _, ok := d.prizes[prizeNo]; !ok {
+		return []Participant{}, ErrPrizeNo
+	}
+
+	prize := d.resolvePercentAmount(prizeNo)
+
+	if err := checkDrawable(prize.Amount); err != nil {
+		return []Participant{}, err
+	}
+
+	if _, ok := d.winners[prizeNo]; ok {
+		return []Participant{}, ErrWinnersExistBeforeDraw
+	}
+
+	// Available participants are sorted by ID.
+	participants := d.availableParticipants(prizeNo)
+	if len(participants) == 0 {
+		d.notePoolExhausted(prizeNo, 0, prize.Amount)
+		return []Participant{}, ErrNoAvailableParticipants
+	}
+
+	if err := d.checkWinnerCap(); err != nil {
+		return []Participant{}, err
+	}
+
+	sort.SliceStable(participants, func(i, j int) bool {
+		return less(participants[i], participants[j])
+	})
+
+	used := make(map[string]bool)
+	winners = []Participant{}
+	for _, p := range participants {
+		if len(winners) >= prize.Amount {
+			break
+		}
+
+		if prize.UniqueGroups && p.Group != "" {
+			if used[p.Group] {
+				continue
+			}
+			used[p.Group] = true
+		}
+
+		winners = append(winners, p)
+	}
+
+	winners = d.capDistinctWinners(winners)
+
+	if len(winners) < prize.Amount {
+		d.notePoolExhausted(prizeNo, len(winners), prize.Amount)
+	}
+
+	d.prizes[prizeNo] = prize
+	d.winners[prizeNo] = copyParticipants(winners)
+	d.indexWinners(prizeNo)
+	d.recordSequence(prizeNo, winners)
+	return winners, nil
+}
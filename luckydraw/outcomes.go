@@ -0,0 +1,85 @@
+package luckydraw
+
+import (
+	"fmt"
+)
+
+// DefaultMaxOutcomes is the default max number of outcomes of PossibleOutcomes.
+const DefaultMaxOutcomes = 10000
+
+// SetMaxOutcomes sets the max number of outcomes PossibleOutcomes returns.
+// 0 means DefaultMaxOutcomes.
+func (d *Draw) SetMaxOutcomes(n int) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.maxOutcomes = n
+}
+
+// PossibleOutcomes returns all possible sets of winners of the prize for tiny pools (e.g. demos and tests):
+// all combinations of the remaining slots of the prize from the available participants.
+// If the available participants are less than the remaining slots, the only outcome is all of them.
+// Participants of each outcome are sorted by ID and outcomes are in lexicographic order.
+// Weights, unique groups and the validator are not considered. Nothing is recorded.
+// It returns ErrTooManyOutcomes if the number of combinations exceeds the max, see SetMaxOutcomes.
+func (d *Draw) PossibleOutcomes(prizeNo int) ([][]Participant, error) {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	outcomes := [][]Participant{}
+
+	prize, ok := d.prizes[prizeNo]
+	if !ok {
+		return outcomes, ErrPrizeNo
+	}
+
+	amount := d.effectiveAmount(prize)
+	if err := checkDrawable(amount); err != nil {
+		return outcomes, err
+	}
+
+	slots := amount - len(d.winners[prizeNo])
+	if slots <= 0 {
+		return outcomes, ErrPrizeFull
+	}
+
+	participants := d.availableParticipants(prizeNo)
+	if len(participants) == 0 {
+		return outcomes, ErrNoAvailableParticipants
+	}
+
+	if slots > len(participants) {
+		slots = len(participants)
+	}
+
+	max := d.maxOutcomes
+	if max <= 0 {
+		max = DefaultMaxOutcomes
+	}
+
+	// Compute C(n, k) incrementally and stop as soon as it exceeds the max.
+	n, count := len(participants), 1
+	for i := 0; i < slots; i++ {
+		count = count * (n - i) / (i + 1)
+		if count > max {
+			return outcomes, fmt.Errorf("more than %d outcomes: %w", max, ErrTooManyOutcomes)
+		}
+	}
+
+	combination := []Participant{}
+	var choose func(start int)
+	choose = func(start int) {
+		if len(combination) == slots {
+			outcomes = append(outcomes, copyParticipants(combination))
+			return
+		}
+		for i := start; i <= n-(slots-len(combination)); i++ {
+			combination = append(combination, participants[i])
+			choose(i + 1)
+			combination = combination[:len(combination)-1]
+		}
+	}
+	choose(0)
+
+	return outcomes, nil
+}
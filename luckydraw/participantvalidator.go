@@ -0,0 +1,28 @@
+package luckydraw
+
+// ParticipantValidator checks a participant before it's added (e.g. the ID format of employee numbers).
+// It returns an error to reject the participant.
+// It's called with the mutex held, so it must not call methods of the draw.
+type ParticipantValidator func(p Participant) error
+
+// SetParticipantValidator sets the participant validator.
+// The participants loaders, SetParticipants, AddParticipant, UpdateParticipantName and Merge
+// reject participants for which the validator returns an error,
+// and the loaders return the error with the row number.
+// Participants are validated after the ID is normalized. nil (default) means no validation.
+// Existing participants are not validated.
+func (d *Draw) SetParticipantValidator(v ParticipantValidator) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.participantValidator = v
+}
+
+// validateParticipant runs the participant validator if it's set.
+// The caller should hold the mutex.
+func (d *Draw) validateParticipant(p Participant) error {
+	if d.participantValidator == nil {
+		return nil
+	}
+	return d.participantValidator(p)
+}
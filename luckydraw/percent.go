@@ -0,0 +1,111 @@
+package luckydraw
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// SetPrizePercent makes the amount of the prize a percentage of the participants (e.g. 5 for the top 5%),
+// so the number of winners scales with the turnout.
+// The amount is resolved when the prize is drawn for the first time:
+// the number of participants * percent / 100 rounded down, but at least 1 if there are participants,
+// and not greater than the max prize amount, see SetMaxPrizeAmount.
+// Until then, Amount of the prize is 0.
+// percent should be in (0, 100]. 0 removes the percentage and the amount is kept.
+// In the prizes CSV, an amount like "5%" sets the percentage.
+func (d *Draw) SetPrizePercent(prizeNo int, percent float64) (err error) {
+	defer func() { d.mutated(err) }()
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.ensureInit()
+
+	if d.finalized {
+		return ErrFinalized
+	}
+
+	prize, ok := d.prizes[prizeNo]
+	if !ok {
+		return ErrPrizeNo
+	}
+
+	if err := checkPercent(percent); err != nil {
+		return err
+	}
+
+	if _, ok := d.winners[prizeNo]; ok {
+		return ErrWinnersExist
+	}
+
+	prize.Percent = percent
+	if percent > 0 {
+		prize.Amount = 0
+	}
+	d.prizes[prizeNo] = prize
+	return nil
+}
+
+// checkPercent returns ErrPrizeAmount if the percentage is not in [0, 100].
+func checkPercent(percent float64) error {
+	if math.IsNaN(percent) || percent < 0 || percent > 100 {
+		return fmt.Errorf("percent %v is not in [0, 100]: %w", percent, ErrPrizeAmount)
+	}
+	return nil
+}
+
+// effectiveAmount returns the amount of the prize with the percentage resolved if it's not drawn yet.
+// The caller should hold the mutex.
+func (d *Draw) effectiveAmount(prize Prize) int {
+	if prize.Percent <= 0 {
+		return prize.Amount
+	}
+
+	if _, ok := d.winners[prize.No]; ok {
+		return prize.Amount
+	}
+
+	return percentAmount(len(d.participants), prize.Percent, d.maxPrizeAmount)
+}
+
+// percentAmount returns percent of n participants rounded down, but at least 1 if n > 0
+// and not greater than max if max > 0.
+func percentAmount(n int, percent float64, max int) int {
+	if n == 0 {
+		return 0
+	}
+
+	// A small epsilon avoids rounding down exact results (e.g. 7% of 100) by floating-point errors.
+	amount := int(math.Floor(float64(n)*percent/100 + 1e-9))
+	if amount < 1 {
+		amount = 1
+	}
+	if max > 0 && amount > max {
+		amount = max
+	}
+	return amount
+}
+
+// resolvePercentAmount returns the prize with its amount set to the effective amount.
+// The prize of the draw is not changed: the caller stores the returned prize with the winners,
+// so the amount is resolved only if the prize is drawn.
+// The caller should hold the mutex and check the prize exists.
+func (d *Draw) resolvePercentAmount(prizeNo int) Prize {
+	prize := d.prizes[prizeNo]
+	prize.Amount = d.effectiveAmount(prize)
+	return prize
+}
+
+// parsePrizeAmount parses the amount column of the prizes CSV.
+// An amount like "5%" returns the percentage and amount 0.
+func parsePrizeAmount(s string) (amount int, percent float64, err error) {
+	if v, ok := strings.CutSuffix(s, "%"); ok {
+		percent, err = strconv.ParseFloat(strings.Trim(v, " "), 64)
+		return 0, percent, err
+	}
+
+	amount, err = strconv.Atoi(s)
+	return amount, 0, err
+}
@@ -0,0 +1,68 @@
+package luckydraw
+
+import (
+	"sort"
+)
+
+// poolCache is the cached available participants of a prize for DrawOne.
+type poolCache struct {
+	prizeNo      int
+	participants []Participant
+}
+
+// SetPoolCache sets if DrawOne caches the available participants of the prize
+// and removes each winner from the cache instead of copying and sorting all participants on every call
+// (e.g. a one by one reveal over a large pool).
+// The cache is dropped by any other change of the draw. It costs a copy of the available participants in memory.
+// Winners are the same with or without the cache. It's off by default.
+func (d *Draw) SetPoolCache(enabled bool) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.poolCacheEnabled = enabled
+	d.poolCache = nil
+}
+
+// invalidatePool drops the cached available participants.
+// It's called by ensureInit, so all mutating methods but DrawOne drop the cache.
+// The caller should hold the mutex.
+func (d *Draw) invalidatePool() {
+	d.poolCache = nil
+}
+
+// cachedAvailableParticipants returns the available participants of the prize like availableParticipants.
+// If the pool cache is enabled, it returns the cache of the prize if it's not dropped by invalidatePool.
+// The returned slice must not be modified.
+// The caller should hold the mutex.
+func (d *Draw) cachedAvailableParticipants(prizeNo int) []Participant {
+	if !d.poolCacheEnabled {
+		return d.availableParticipants(prizeNo)
+	}
+
+	if c := d.poolCache; c != nil && c.prizeNo == prizeNo {
+		return c.participants
+	}
+
+	participants := d.availableParticipants(prizeNo)
+	d.poolCache = &poolCache{prizeNo, participants}
+	return participants
+}
+
+// removeFromPoolCache removes the new winner of the prize from the cache.
+// The winner is found by a binary search, but the removal shifts the rest of the cache to keep it sorted,
+// so it's O(n). It still saves copying and sorting all participants.
+// Winners stay available in raffle mode.
+// The caller should hold the mutex.
+func (d *Draw) removeFromPoolCache(prizeNo int, winner Participant) {
+	c := d.poolCache
+	if c == nil || c.prizeNo != prizeNo || d.raffle {
+		return
+	}
+
+	// The cache is sorted by ID like availableParticipants.
+	participants := c.participants
+	i := sort.Search(len(participants), func(i int) bool { return participants[i].ID >= winner.ID })
+	if i < len(participants) && participants[i].ID == winner.ID {
+		c.participants = append(participants[:i], participants[i+1:]...)
+	}
+}
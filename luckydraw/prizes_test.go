@@ -0,0 +1,36 @@
+package luckydraw
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestLoadPrizesCSVKeepsPrizesOnError(t *testing.T) {
+	d := New("prizes")
+	if err := d.LoadPrizesCSV(strings.NewReader("no,name,amount,desc\n1,old 1,1,\n2,old 2,2,\n")); err != nil {
+		t.Fatal(err)
+	}
+	want := d.Prizes(false)
+
+	for _, c := range []struct {
+		name string
+		csv  string
+		err  error
+	}{
+		{"bad no", "no,name,amount,desc\n1,new 1,1,\n2,new 2,2,\nx,new 3,3,\n", strconv.ErrSyntax},
+		{"zero no", "no,name,amount,desc\n1,new 1,1,\n2,new 2,2,\n0,new 3,3,\n", ErrPrizeNo},
+		{"duplicate no", "no,name,amount,desc\n1,new 1,1,\n2,new 2,2,\n1,new 3,3,\n", ErrPrizeExists},
+		{"short row", "no,name,amount,desc\n1,new 1,1,\n2,new 2,2,\n3,new 3\n", nil},
+	} {
+		err := d.LoadPrizesCSV(strings.NewReader(c.csv))
+		if err == nil || (c.err != nil && !errors.Is(err, c.err)) {
+			t.Errorf("%s: got %v, want %v", c.name, err, c.err)
+		}
+		if got := d.Prizes(false); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: prizes changed after failed load: %v, want %v", c.name, got, want)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package luckydraw
+
+import (
+	"math/rand"
+)
+
+// SetRaffleMode sets if the draw works as a raffle.
+//
+// In raffle mode, participants hold tickets and each winner is a winning ticket:
+// every slot of a prize is drawn from all tickets with replacement,
+// so a participant with more tickets is more likely to win
+// and the same participant can win many prizes and even many slots of the same prize.
+// A participant appears in the winners of a prize once for each winning ticket.
+// Revoking a participant revokes all winning tickets of the participant for the prize.
+//
+// Each row of the participants CSV loaded by LoadParticipantsCSV is a ticket,
+// so the same ID can be repeated. A participant without tickets holds 1 ticket, see AddTickets.
+// Multi-win, weights and unique groups are not applied in raffle mode.
+//
+// It returns ErrWinnersExist if any winners exist.
+func (d *Draw) SetRaffleMode(raffle bool) (err error) {
+	defer func() { d.mutated(err) }()
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.ensureInit()
+
+	if d.finalized {
+		return ErrFinalized
+	}
+
+	for _, winners := range d.winners {
+		if len(winners) > 0 {
+			return ErrWinnersExist
+		}
+	}
+
+	d.raffle = raffle
+	return nil
+}
+
+// AddTickets adds n tickets to the participant for raffle mode.
+func (d *Draw) AddTickets(id string, n int) (err error) {
+	defer func() { d.mutated(err) }()
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.ensureInit()
+
+	id = d.normalizeID(id)
+
+	if d.finalized {
+		return ErrFinalized
+	}
+
+	if _, ok := d.participants[id]; !ok {
+		return ErrParticipantNotFound
+	}
+
+	d.tickets[id] = d.ticketsOf(id) + n
+	return nil
+}
+
+// Tickets returns the number of tickets of the participant for raffle mode.
+func (d *Draw) Tickets(id string) int {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	id = d.normalizeID(id)
+
+	if _, ok := d.participants[id]; !ok {
+		return 0
+	}
+	return d.ticketsOf(id)
+}
+
+// ticketsOf returns the number of tickets of the participant.
+// The caller should hold the mutex.
+func (d *Draw) ticketsOf(id string) int {
+	if n, ok := d.tickets[id]; ok {
+		return n
+	}
+	return 1
+}
+
+// drawRaffle draws amount tickets of participants with replacement.
+// It picks a uniform integer in [0, total tickets) for each slot like drawWeighted.
+// The caller should hold the mutex.
+func (d *Draw) drawRaffle(r *rand.Rand, amount int, participants []Participant) []Participant {
+	winners := []Participant{}
+
+	var total int64
+	for _, p := range participants {
+		if n := d.ticketsOf(p.ID); n > 0 {
+			total += int64(n)
+		}
+	}
+
+	if total == 0 {
+		return winners
+	}
+
+	for len(winners) < amount {
+		n := r.Int63n(total)
+		for _, p := range participants {
+			tickets := int64(d.ticketsOf(p.ID))
+			if tickets <= 0 {
+				continue
+			}
+			if n < tickets {
+				winners = append(winners, p)
+				break
+			}
+			n -= tickets
+		}
+	}
+
+	return winners
+}
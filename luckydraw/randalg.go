@@ -0,0 +1,151 @@
+package luckydraw
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"math/rand"
+	randv2 "math/rand/v2"
+)
+
+const (
+	// RandGo1 is the generator of math/rand, used by draws saved before rand algorithms were added.
+	RandGo1 = "go1"
+	// RandPCG is the PCG generator of math/rand/v2.
+	RandPCG = "pcg"
+	// RandChaCha8 is the ChaCha8 generator of math/rand/v2, which is cryptographically strong.
+	RandChaCha8 = "chacha8"
+)
+
+// DefaultRandAlgorithm is the rand algorithm of draws created by New.
+// Draws created by NewWithSeed use RandGo1, see NewWithSeed.
+const DefaultRandAlgorithm = RandPCG
+
+// SetRandAlgorithm sets the algorithm of the random source of the draw (e.g. RandChaCha8)
+// and reseeds it with the seed of the draw, so the winners are still reproducible with the seed and the algorithm.
+// The algorithm is saved with the state of the random source.
+// It returns ErrRandAlgorithm if the algorithm is not supported
+// and ErrWinnersExist if any winner is drawn, because the draws couldn't be replayed with one algorithm.
+// DrawWithSeed and VerifyDraw always use RandGo1, so published seeds can still be verified.
+func (d *Draw) SetRandAlgorithm(algorithm string) (err error) {
+	defer func() { d.mutated(err) }()
+
+	if _, err := newRandGenerator(algorithm, 0); err != nil {
+		return err
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.ensureInit()
+
+	if d.finalized {
+		return ErrFinalized
+	}
+
+	if len(d.winners) > 0 {
+		return ErrWinnersExist
+	}
+
+	d.src = newRandSource(algorithm, d.src.seed)
+	d.rnd = rand.New(d.src)
+	return nil
+}
+
+// RandAlgorithm returns the algorithm of the random source of the draw.
+func (d *Draw) RandAlgorithm() string {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	if d.src == nil {
+		return DefaultRandAlgorithm
+	}
+	if d.src.algorithm == "" {
+		return RandGo1
+	}
+	return d.src.algorithm
+}
+
+// Seed returns the seed of the random source of the draw,
+// so the draws can be reproduced with NewWithSeed (e.g. for a dry run or a dispute after the event).
+// The seed is saved with the state of the random source and covered by the checksum.
+// If the seed is committed by CommitSeed, it's kept secret like RevealSeed:
+// it returns ErrSeedNotRevealed until the draws are done.
+func (d *Draw) Seed() (int64, error) {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	if d.src == nil {
+		return 0, nil
+	}
+	if d.commitment != "" && !d.seedRevealable() {
+		return 0, ErrSeedNotRevealed
+	}
+	return d.src.seed, nil
+}
+
+// newSeed returns a random seed from the securely auto-seeded generator of math/rand/v2.
+func newSeed() int64 {
+	return randv2.Int64()
+}
+
+// newRandGenerator returns the generator of the algorithm with the seed.
+// Empty algorithm means RandGo1 for compatibility with old data files.
+func newRandGenerator(algorithm string, seed int64) (rand.Source, error) {
+	switch algorithm {
+	case "", RandGo1:
+		return rand.NewSource(seed), nil
+	case RandPCG, RandChaCha8:
+		src := &v2Source{algorithm: algorithm}
+		src.Seed(seed)
+		return src, nil
+	default:
+		return nil, ErrRandAlgorithm
+	}
+}
+
+// MaxRandCount is the max count of a saved random state.
+// Restoring the state generates Count values, so a larger count (e.g. of a tampered file) is rejected
+// instead of hanging the load. It's far more than the values used by any real event.
+const MaxRandCount = 1 << 26
+
+// checkRandState checks if the algorithm of the saved random source is supported
+// and the count is not over MaxRandCount.
+func checkRandState(state *RandState) error {
+	if state == nil {
+		return nil
+	}
+
+	if _, err := newRandGenerator(state.Algorithm, 0); err != nil {
+		return err
+	}
+
+	if state.Count > MaxRandCount {
+		return fmt.Errorf("count %d exceeds %d: %w", state.Count, MaxRandCount, ErrRandState)
+	}
+	return nil
+}
+
+// v2Source adapts a generator of math/rand/v2 to rand.Source,
+// so it can be used by rand.Rand and counted by randSource.
+type v2Source struct {
+	algorithm string
+	gen       randv2.Source
+}
+
+func (s *v2Source) Int63() int64 {
+	return int64(s.gen.Uint64() >> 1)
+}
+
+// Seed recreates the generator with the seed.
+// The seed of ChaCha8 is the SHA-256 hash of the seed in big endian.
+func (s *v2Source) Seed(seed int64) {
+	switch s.algorithm {
+	case RandPCG:
+		s.gen = randv2.NewPCG(uint64(seed), 0)
+	case RandChaCha8:
+		buf := make([]byte, 8)
+		binary.BigEndian.PutUint64(buf, uint64(seed))
+		s.gen = randv2.NewChaCha8(sha256.Sum256(buf))
+	}
+}
@@ -0,0 +1,57 @@
+package luckydraw
+
+import (
+	"math"
+)
+
+// PenaltyFunc returns the penalty factor of a participant in [0, 1] for weighted draws
+// (e.g. 0.25 if the participant won last week, 0.5 if two weeks ago and 1 if never).
+// The weight of the participant is multiplied by the factor.
+// Factors are clamped to [0, 1] and a participant with factor 0 is never picked.
+type PenaltyFunc func(p Participant) float64
+
+// penaltyScale scales weights before penalty factors are applied,
+// so penalized weights are still integers with 3 decimal digits of precision.
+const penaltyScale = 1000
+
+// SetRecencyPenalty sets the penalty function of the draw
+// to lower the chance of recent winners of recurring events without excluding them.
+// It's applied multiplicatively on top of the weight function (see SetWeightFunc)
+// or on weight 1 if there's no weight function.
+// nil (default) means no penalty.
+// f is called with the mutex held, so it must not call methods of the draw.
+func (d *Draw) SetRecencyPenalty(f PenaltyFunc) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.penaltyFunc = f
+}
+
+// weightFuncWithPenalty returns the weight function with the recency penalty applied.
+// It returns nil if there's neither a weight function nor a penalty function.
+// The caller should hold the mutex.
+func (d *Draw) weightFuncWithPenalty() WeightFunc {
+	if d.penaltyFunc == nil {
+		return d.weightFunc
+	}
+
+	weight, penalty := d.weightFunc, d.penaltyFunc
+	return func(p Participant) int {
+		w := 1
+		if weight != nil {
+			w = weight(p)
+		}
+		if w <= 0 {
+			return 0
+		}
+
+		f := penalty(p)
+		if math.IsNaN(f) || f < 0 {
+			f = 0
+		} else if f > 1 {
+			f = 1
+		}
+
+		return int(math.Round(float64(w) * penaltyScale * f))
+	}
+}
@@ -0,0 +1,58 @@
+package luckydraw
+
+import (
+	"testing"
+)
+
+func TestRedrawPrize(t *testing.T) {
+	d := newSeededDraw(t, 42)
+	old, err := d.Draw(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	winners, err := d.ReplacePrizeDraw(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(winners) != 3 {
+		t.Errorf("got %d winners, want 3", len(winners))
+	}
+	if got := d.Winners(1); len(got) != 3 {
+		t.Errorf("Winners: got %v", got)
+	}
+
+	// The discarded winners are recorded.
+	revoked := d.Revoked(1)
+	if len(revoked) != len(old) {
+		t.Fatalf("got %d revoked winners, want %d", len(revoked), len(old))
+	}
+	for i, r := range revoked {
+		if r.ID != old[i].ID || r.Reason != "redraw" {
+			t.Errorf("revoked %d: got %s %q, want %s \"redraw\"", i, r.ID, r.Reason, old[i].ID)
+		}
+	}
+
+	if _, err := d.RedrawPrize(99); err != ErrPrizeNo {
+		t.Errorf("got %v, want ErrPrizeNo", err)
+	}
+}
+
+func TestRedrawPercentPrize(t *testing.T) {
+	d := newSeededDraw(t, 42)
+	// 10% of 50 participants is 5.
+	if err := d.SetPrizePercent(1, 10); err != nil {
+		t.Fatal(err)
+	}
+
+	winners, err := d.RedrawPrize(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(winners) != 5 {
+		t.Errorf("got %d winners, want 5", len(winners))
+	}
+	if err := d.Save(&discard{}); err != nil {
+		t.Errorf("Save: %v", err)
+	}
+}
@@ -0,0 +1,21 @@
+package luckydraw
+
+import (
+	"time"
+)
+
+// SetRegistrationDeadline sets the registration cutoff time of the event.
+// Once the clock (see SetClock) reaches the deadline, AddParticipant, SetParticipants, Merge and the participants loaders
+// return ErrRegistrationClosed. The zero time (default) means no deadline.
+func (d *Draw) SetRegistrationDeadline(deadline time.Time) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.registrationDeadline = deadline
+}
+
+// registrationClosed reports whether the registration deadline has passed.
+// The caller should hold the mutex.
+func (d *Draw) registrationClosed() bool {
+	return !d.registrationDeadline.IsZero() && !d.now().Before(d.registrationDeadline)
+}
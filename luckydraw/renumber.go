@@ -0,0 +1,85 @@
+package luckydraw
+
+import (
+	"fmt"
+)
+
+// RenumberPrize changes the no of the prize (e.g. to correct a configuration mistake).
+// The prize moves to the new no with its winners, revoke history, alternates, draw sequence,
+// frozen pool and eligibility predicate, so the checksum of the saved data stays consistent.
+// It returns ErrPrizeNo if the old prize doesn't exist or the new no is not positive,
+// and ErrPrizeExists if the new no is used by another prize.
+func (d *Draw) RenumberPrize(oldNo, newNo int) (err error) {
+	defer func() { d.mutated(err) }()
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.ensureInit()
+
+	if d.finalized {
+		return ErrFinalized
+	}
+
+	prize, ok := d.prizes[oldNo]
+	if !ok {
+		return fmt.Errorf("prize no %d: %w", oldNo, ErrPrizeNo)
+	}
+
+	if oldNo == newNo {
+		return nil
+	}
+
+	if newNo <= 0 {
+		return fmt.Errorf("prize no %d: %w", newNo, ErrPrizeNo)
+	}
+
+	if _, ok := d.prizes[newNo]; ok {
+		return fmt.Errorf("prize no %d: %w", newNo, ErrPrizeExists)
+	}
+
+	prize.No = newNo
+	d.prizes[newNo] = prize
+	delete(d.prizes, oldNo)
+
+	if winners, ok := d.winners[oldNo]; ok {
+		d.winners[newNo] = winners
+		delete(d.winners, oldNo)
+		d.indexWinners(oldNo)
+		d.indexWinners(newNo)
+	}
+
+	if revoked, ok := d.revoked[oldNo]; ok {
+		d.revoked[newNo] = revoked
+		delete(d.revoked, oldNo)
+	}
+
+	if alternates, ok := d.alternates[oldNo]; ok {
+		d.alternates[newNo] = alternates
+		delete(d.alternates, oldNo)
+	}
+
+	if sequence, ok := d.sequences[oldNo]; ok {
+		d.sequences[newNo] = sequence
+		delete(d.sequences, oldNo)
+	}
+
+	if ids, ok := d.frozenPools[oldNo]; ok {
+		d.frozenPools[newNo] = ids
+		delete(d.frozenPools, oldNo)
+	}
+
+	if pred, ok := d.eligibility[oldNo]; ok {
+		d.eligibility[newNo] = pred
+		delete(d.eligibility, oldNo)
+	}
+
+	for key, op := range d.drawOps {
+		if op.prizeNo == oldNo {
+			op.prizeNo = newNo
+			d.drawOps[key] = op
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,27 @@
+package luckydraw
+
+// PrizeReport is a prize with its current winners for prize-centric reports.
+type PrizeReport struct {
+	Prize Prize `json:"prize"`
+	// Winners are in draw order.
+	Winners []Participant `json:"winners"`
+	// RemainingSlots is the number of winners still to be drawn.
+	RemainingSlots int `json:"remaining_slots"`
+}
+
+// PrizeReports returns all prizes sorted by prize no with their winners and remaining slots.
+// It's read in one lock, so the report is consistent
+// unlike calling Prizes and then Winners of each prize.
+func (d *Draw) PrizeReports(descOrder bool) []PrizeReport {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	reports := []PrizeReport{}
+	for _, prize := range prizeMapToSlice(d.prizes, descOrder) {
+		winners := make([]Participant, len(d.winners[prize.No]))
+		copy(winners, d.winners[prize.No])
+		reports = append(reports, PrizeReport{prize, winners, d.remainingSlots(prize.No)})
+	}
+
+	return reports
+}
@@ -0,0 +1,106 @@
+package luckydraw
+
+import (
+	"time"
+)
+
+// DrawResult is the result of a draw with its context.
+type DrawResult struct {
+	// PrizeNo is the prize no of the draw.
+	PrizeNo int `json:"prize_no"`
+	// Winners are the winners drawn by the draw.
+	Winners []Participant `json:"winners"`
+	// Truncated is true if less winners than requested are drawn
+	// because the available participants run out.
+	Truncated bool `json:"truncated"`
+	// At is the time of the draw, see SetClock.
+	At time.Time `json:"at"`
+}
+
+// DrawWithResult draws the prize like Draw and returns the result with its context.
+func (d *Draw) DrawWithResult(prizeNo int) (result DrawResult, err error) {
+	defer func() { d.mutated(err) }()
+	defer func() { d.observeDraw(prizeNo, result.Winners, err) }()
+	defer func(start time.Time) {
+		d.logOp("DrawWithResult", start, err, "prize_no", prizeNo, "winners", len(result.Winners))
+	}(time.Now())
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.ensureInit()
+
+	result = DrawResult{PrizeNo: prizeNo, Winners: []Participant{}, At: d.now()}
+
+	if d.finalized {
+		return result, ErrFinalized
+	}
+
+	winners, err := d.drawPrize(prizeNo)
+	if err != nil {
+		return result, err
+	}
+
+	result.Winners = winners
+	result.Truncated = len(winners) < d.prizes[prizeNo].Amount
+	return result, nil
+}
+
+// RedrawWithResult draws amount more winners of the prize like Redraw and returns the result with its context.
+// If the available participants run out, the drawn winners are returned with Truncated set
+// instead of ErrPartialDraw.
+func (d *Draw) RedrawWithResult(prizeNo int, amount int) (result DrawResult, err error) {
+	defer func() { d.mutated(err) }()
+	defer func() { d.observeRedraw(prizeNo, result.Winners, err) }()
+	defer func(start time.Time) {
+		d.logOp("RedrawWithResult", start, err, "prize_no", prizeNo, "winners", len(result.Winners))
+	}(time.Now())
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.ensureInit()
+
+	result = DrawResult{PrizeNo: prizeNo, Winners: []Participant{}, At: d.now()}
+
+	if d.finalized {
+		return result, ErrFinalized
+	}
+
+	winners, err := d.redraw(prizeNo, amount)
+	if err != nil && err != ErrPartialDraw {
+		return result, err
+	}
+
+	result.Winners = winners
+	result.Truncated = err == ErrPartialDraw
+	return result, nil
+}
+
+// DrawOneWithResult draws one more winner of the prize like DrawOne and returns the result with its context.
+func (d *Draw) DrawOneWithResult(prizeNo int) (result DrawResult, err error) {
+	defer func() { d.mutated(err) }()
+	defer func() { d.observeDraw(prizeNo, result.Winners, err) }()
+	defer func(start time.Time) {
+		d.logOp("DrawOneWithResult", start, err, "prize_no", prizeNo, "winners", len(result.Winners))
+	}(time.Now())
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.ensureInit()
+
+	result = DrawResult{PrizeNo: prizeNo, Winners: []Participant{}, At: d.now()}
+
+	if d.finalized {
+		return result, ErrFinalized
+	}
+
+	winners, err := d.drawOne(prizeNo)
+	if err != nil {
+		return result, err
+	}
+
+	result.Winners = winners
+	return result, nil
+}
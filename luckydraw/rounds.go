@@ -0,0 +1,162 @@
+package luckydraw
+
+import (
+	"sort"
+)
+
+// Exclude excludes participants from the following draws.
+// IDs which are not participants are excluded too,
+// so they'll be excluded once they're added as participants.
+func (d *Draw) Exclude(ids ...string) (err error) {
+	defer func() { d.mutated(err) }()
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.ensureInit()
+
+	if d.finalized {
+		return ErrFinalized
+	}
+
+	for _, id := range ids {
+		d.excluded[d.normalizeID(id)] = true
+	}
+	return nil
+}
+
+// Include makes excluded participants available for the following draws again.
+func (d *Draw) Include(ids ...string) (err error) {
+	defer func() { d.mutated(err) }()
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.ensureInit()
+
+	if d.finalized {
+		return ErrFinalized
+	}
+
+	for _, id := range ids {
+		delete(d.excluded, d.normalizeID(id))
+	}
+	return nil
+}
+
+// Excluded returns IDs of excluded participants sorted by ID.
+func (d *Draw) Excluded() []string {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	return d.excludedIDs()
+}
+
+func (d *Draw) excludedIDs() []string {
+	ids := []string{}
+	for id := range d.excluded {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// CarryOverWinnersToExclusions excludes all current winners from the following draws.
+// Winners are not cleared.
+func (d *Draw) CarryOverWinnersToExclusions() (err error) {
+	defer func() { d.mutated(err) }()
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.ensureInit()
+
+	if d.finalized {
+		return ErrFinalized
+	}
+
+	d.carryOverWinners()
+	return nil
+}
+
+func (d *Draw) carryOverWinners() {
+	for _, winners := range d.winners {
+		for _, winner := range winners {
+			d.excluded[winner.ID] = true
+		}
+	}
+}
+
+// StartNewRound starts a new round of draws over the same participants and prizes.
+// Winners of the current round are excluded from the new round and all winners are cleared.
+// Use Include to make them available again.
+func (d *Draw) StartNewRound() (err error) {
+	defer func() { d.mutated(err) }()
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.ensureInit()
+
+	if d.finalized {
+		return ErrFinalized
+	}
+
+	d.carryOverWinners()
+	d.winners = make(map[int][]Participant)
+	d.reindexWinners()
+	return nil
+}
+
+// RewindRNG resets the random source of the draw to its initial state of the seed,
+// so clearing winners (see ClearAllWinners) and drawing again with the same participants and prizes
+// reproduces the same winners (e.g. for rehearsals and demos).
+// The random source is saved with its state, so Save after RewindRNG saves the rewound state.
+func (d *Draw) RewindRNG() (err error) {
+	defer func() { d.mutated(err) }()
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.ensureInit()
+
+	if d.finalized {
+		return ErrFinalized
+	}
+
+	// Rand.Seed also resets the buffered state of the generator.
+	d.rnd.Seed(d.src.seed)
+	return nil
+}
+
+// ExcludeFromSaveFile excludes the winners of another event saved in the file (e.g. last month's draw of a series),
+// so they can't win again. The checksum of the file is verified.
+// Only the winners are read from the file and nothing else of the draw is changed.
+func (d *Draw) ExcludeFromSaveFile(file string) (err error) {
+	defer func() { d.mutated(err) }()
+
+	data, err := readSaveFile(file)
+	if err != nil {
+		return err
+	}
+
+	if data.Version > SaveDataVersion {
+		return ErrSaveDataVersion
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.ensureInit()
+
+	if d.finalized {
+		return ErrFinalized
+	}
+
+	for _, winners := range data.Winners {
+		for _, winner := range winners {
+			d.excluded[d.normalizeID(winner.ID)] = true
+		}
+	}
+	return nil
+}
@@ -0,0 +1,18 @@
+package luckydraw
+
+// SaveValidator checks the data before Save writes it
+// (e.g. every prize must be fully drawn before saving the final file).
+// It returns an error to abort the save.
+// It's called with the mutex held, so it must not call methods of the draw:
+// the data is exactly what's going to be written.
+type SaveValidator func(data *SaveData) error
+
+// AddSaveValidator adds a save validator.
+// Save, SaveCompact and SaveToFile run the validators in the order they're added
+// and return the error of the first failed validator without writing anything.
+func (d *Draw) AddSaveValidator(v SaveValidator) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.saveValidators = append(d.saveValidators, v)
+}
@@ -0,0 +1,90 @@
+package luckydraw
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+func TestJSONSchemaGolden(t *testing.T) {
+	got, err := JSONSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	golden := filepath.Join("testdata", "schema.json")
+	if *update {
+		if err := os.WriteFile(golden, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(got, want) {
+		t.Errorf("JSONSchema differs from %s, run go test -update if the change is intended:\n%s", golden, got)
+	}
+}
+
+func TestJSONSchemaValid(t *testing.T) {
+	buf, err := JSONSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schema := map[string]any{}
+	if err := json.Unmarshal(buf, &schema); err != nil {
+		t.Fatal(err)
+	}
+
+	if schema["$schema"] != "https://json-schema.org/draft/2020-12/schema" {
+		t.Errorf("$schema: %v", schema["$schema"])
+	}
+
+	defs, ok := schema["$defs"].(map[string]any)
+	if !ok {
+		t.Fatalf("$defs: %v", schema["$defs"])
+	}
+
+	for _, name := range []string{"Participant", "Prize", "DrawResult", "ErrorInfo"} {
+		def, ok := defs[name].(map[string]any)
+		if !ok {
+			t.Errorf("%s not in $defs", name)
+			continue
+		}
+		if def["type"] != "object" {
+			t.Errorf("%s: type %v", name, def["type"])
+		}
+	}
+
+	// Every $ref resolves to a definition.
+	var walk func(v any)
+	walk = func(v any) {
+		switch v := v.(type) {
+		case map[string]any:
+			if ref, ok := v["$ref"].(string); ok {
+				name, ok := strings.CutPrefix(ref, "#/$defs/")
+				if _, found := defs[name]; !ok || !found {
+					t.Errorf("unresolved $ref %s", ref)
+				}
+			}
+			for _, e := range v {
+				walk(e)
+			}
+		case []any:
+			for _, e := range v {
+				walk(e)
+			}
+		}
+	}
+	walk(schema)
+}
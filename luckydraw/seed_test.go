@@ -0,0 +1,164 @@
+package luckydraw
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newSeededDraw creates a draw with the seed, 3 prizes and 50 participants.
+func newSeededDraw(t *testing.T, seed int64) *Draw {
+	t.Helper()
+
+	d := NewWithSeed("seed", seed)
+	for no := 1; no <= 3; no++ {
+		if err := d.SetPrize(no, fmt.Sprintf("prize %d", no), 3, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i := 0; i < 50; i++ {
+		if err := d.AddParticipant(Participant{ID: fmt.Sprintf("%03d", i), Name: fmt.Sprintf("name %d", i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return d
+}
+
+func TestNewWithSeedReproducible(t *testing.T) {
+	a := newSeededDraw(t, 42)
+	b := newSeededDraw(t, 42)
+
+	for _, d := range []*Draw{a, b} {
+		for no := 1; no <= 3; no++ {
+			if _, err := d.Draw(no); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if _, err := d.Revoke(2, d.Winners(2)[:1]); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := d.Redraw(2, 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ja, err := json.Marshal(a.AllWinners())
+	if err != nil {
+		t.Fatal(err)
+	}
+	jb, err := json.Marshal(b.AllWinners())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(ja, jb) {
+		t.Errorf("AllWinners differ:\n%s\n%s", ja, jb)
+	}
+}
+
+func TestLoadContinuesRandSequence(t *testing.T) {
+	a := newSeededDraw(t, 7)
+	if _, err := a.Draw(1); err != nil {
+		t.Fatal(err)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := a.Save(buf); err != nil {
+		t.Fatal(err)
+	}
+
+	b := New("seed")
+	if err := b.Load(buf); err != nil {
+		t.Fatal(err)
+	}
+
+	wa, err := a.Draw(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wb, err := b.Draw(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if fmt.Sprint(wa) != fmt.Sprint(wb) {
+		t.Errorf("winners after load differ: %v, %v", wa, wb)
+	}
+}
+
+func TestTamperedSeedDetected(t *testing.T) {
+	d := newSeededDraw(t, 42)
+
+	buf := &bytes.Buffer{}
+	if err := d.Save(buf); err != nil {
+		t.Fatal(err)
+	}
+	s := buf.String()
+
+	tampered := strings.Replace(s, `"seed": 42`, `"seed": 43`, 1)
+	if tampered == s {
+		t.Fatal("seed not found in save data")
+	}
+
+	// The version is not trusted.
+	for _, version := range []string{`"version": 2`, `"version": 0`} {
+		data := strings.Replace(tampered, fmt.Sprintf(`"version": %d`, SaveDataVersion), version, 1)
+		if err := VerifyReader(strings.NewReader(data)); !errors.Is(err, ErrChecksum) {
+			t.Errorf("%s: got %v, want ErrChecksum", version, err)
+		}
+		if err := New("seed").Load(strings.NewReader(data)); !errors.Is(err, ErrChecksum) {
+			t.Errorf("%s: Load got %v, want ErrChecksum", version, err)
+		}
+	}
+}
+
+func TestLoadVersion1Data(t *testing.T) {
+	// v1.json is written by Save of the first release.
+	data, err := os.ReadFile(filepath.Join("testdata", "v1.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := New("v1")
+	if err := d.Load(bytes.NewReader(data)); err != nil {
+		t.Fatal(err)
+	}
+	if got := d.Winners(1); len(got) != 2 {
+		t.Errorf("Winners(1): got %v", got)
+	}
+
+	// It's saved with the checksum of the current version.
+	buf := &bytes.Buffer{}
+	if err := d.Save(buf); err != nil {
+		t.Fatal(err)
+	}
+	if err := New("v1").Load(buf); err != nil {
+		t.Errorf("Load after migration: %v", err)
+	}
+
+	// Fields of later versions are not accepted with the checksum of version 1.
+	s := strings.Replace(string(data), `"name": "v1",`, `"name": "v1", "rand_state": {"seed": 1, "count": 0},`, 1)
+	if err := New("v1").Load(strings.NewReader(s)); !errors.Is(err, ErrChecksum) {
+		t.Errorf("rand state: got %v, want ErrChecksum", err)
+	}
+
+	// Tampered winners are detected.
+	var v map[string]any
+	if err := json.Unmarshal(data, &v); err != nil {
+		t.Fatal(err)
+	}
+	winners := v["winners"].(map[string]any)["1"].([]any)
+	winners[0] = map[string]any{"id": "009", "name": "name 9"}
+	tampered, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := New("v1").Load(bytes.NewReader(tampered)); !errors.Is(err, ErrChecksum) {
+		t.Errorf("tampered winners: got %v, want ErrChecksum", err)
+	}
+}
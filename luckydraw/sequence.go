@@ -0,0 +1,26 @@
+package luckydraw
+
+// DrawSequence returns IDs of all winners drawn for the prize in pick order,
+// including winners drawn by Redraw and RedrawPrize and winners revoked later.
+// With the seed of the draw (see RandState and NewWithSeed), it can be used to replay and verify the draws.
+// It's kept across ClearWinners for audit.
+func (d *Draw) DrawSequence(prizeNo int) []string {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	sequence := make([]string, len(d.sequences[prizeNo]))
+	copy(sequence, d.sequences[prizeNo])
+	return sequence
+}
+
+// recordSequence appends IDs of drawn winners of the prize to the draw sequence.
+// The caller should hold the mutex.
+func (d *Draw) recordSequence(prizeNo int, winners []Participant) {
+	if d.sequences == nil {
+		d.sequences = make(map[int][]string)
+	}
+
+	for _, winner := range winners {
+		d.sequences[prizeNo] = append(d.sequences[prizeNo], winner.ID)
+	}
+}
@@ -0,0 +1,40 @@
+package luckydraw
+
+// Deactivate soft-deletes the participant: it drops out of later draws
+// but stays in the participants flagged as inactive, see Participant.Inactive.
+// Prizes the participant has won are not affected, use Revoke to remove them.
+// It returns ErrParticipantNotFound if the participant doesn't exist.
+func (d *Draw) Deactivate(id string) error {
+	return d.setInactive(id, true)
+}
+
+// Reactivate undoes Deactivate, so the participant can win again.
+// It returns ErrParticipantNotFound if the participant doesn't exist.
+func (d *Draw) Reactivate(id string) error {
+	return d.setInactive(id, false)
+}
+
+// setInactive sets the inactive flag of the participant.
+func (d *Draw) setInactive(id string, inactive bool) (err error) {
+	defer func() { d.mutated(err) }()
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.ensureInit()
+
+	id = d.normalizeID(id)
+
+	if d.finalized {
+		return ErrFinalized
+	}
+
+	p, ok := d.participants[id]
+	if !ok {
+		return ErrParticipantNotFound
+	}
+
+	p.Inactive = inactive
+	d.participants[id] = p
+	return nil
+}
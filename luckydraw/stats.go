@@ -0,0 +1,115 @@
+package luckydraw
+
+// Stats is the summary of a draw.
+type Stats struct {
+	// Participants is the number of participants.
+	Participants int `json:"participants"`
+	// Prizes is the number of prizes.
+	Prizes int `json:"prizes"`
+	// Slots is the sum of prize amounts.
+	Slots int `json:"slots"`
+	// Winners is the number of current winners of all prizes. Revoked winners are not counted.
+	Winners int `json:"winners"`
+	// RemainingSlots is the number of winners still to be drawn of all prizes.
+	RemainingSlots int `json:"remaining_slots"`
+	// DrawnPrizes is the number of prizes whose winners are full.
+	// Display-only prizes (amount 0) are not counted.
+	DrawnPrizes int `json:"drawn_prizes"`
+}
+
+// Stats returns the summary of the draw.
+func (d *Draw) Stats() Stats {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	stats := Stats{
+		Participants: len(d.participants),
+		Prizes:       len(d.prizes),
+	}
+
+	for no, prize := range d.prizes {
+		amount := d.effectiveAmount(prize)
+		stats.Slots += amount
+		remaining := d.remainingSlots(no)
+		stats.RemainingSlots += remaining
+		if remaining == 0 && amount > 0 {
+			stats.DrawnPrizes++
+		}
+	}
+
+	for _, winners := range d.winners {
+		stats.Winners += len(winners)
+	}
+
+	return stats
+}
+
+// TotalSlots returns the sum of the amounts of all prizes.
+// The amounts of percentage prizes which are not drawn yet are resolved with the current participants, see SetPrizePercent.
+func (d *Draw) TotalSlots() int {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	return d.totalSlots()
+}
+
+// totalSlots returns the sum of the effective amounts of all prizes, see effectiveAmount.
+// The caller should hold the mutex.
+func (d *Draw) totalSlots() int {
+	slots := 0
+	for _, prize := range d.prizes {
+		slots += d.effectiveAmount(prize)
+	}
+	return slots
+}
+
+// CapacityCheck compares the number of participants with the total slots of prizes
+// to plan an event before drawing (e.g. reduce prize amounts if it's under-subscribed).
+// cmp is -1 if participants are less than slots, 0 if equal and +1 if greater.
+// gap is participants minus slots.
+// It's a planning helper: drawing is still allowed in any case.
+func (d *Draw) CapacityCheck() (cmp int, gap int) {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	gap = len(d.participants) - d.totalSlots()
+	switch {
+	case gap < 0:
+		return -1, gap
+	case gap > 0:
+		return 1, gap
+	}
+	return 0, gap
+}
+
+// RemainingAfter returns the number of participants which would be available for other prizes
+// if the given prizes were drawn now, e.g. to decide to draw the prizes with big pools first.
+// Each given prize is expected to draw its remaining slots.
+// Nothing is drawn.
+// If multi-win or raffle mode is on, winners are still available for other prizes,
+// so it's the number of participants available now.
+// Per-prize eligibility (see SetPrizeEligibility) is not applied.
+func (d *Draw) RemainingAfter(prizeNos ...int) int {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	// 0 is not a prize no, so no prize specific rules are applied.
+	n := len(d.availableParticipants(0))
+	if d.multiWin || d.raffle {
+		return n
+	}
+
+	seen := make(map[int]bool)
+	for _, prizeNo := range prizeNos {
+		if seen[prizeNo] {
+			continue
+		}
+		seen[prizeNo] = true
+		n -= d.remainingSlots(prizeNo)
+	}
+
+	if n < 0 {
+		return 0
+	}
+	return n
+}
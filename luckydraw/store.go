@@ -0,0 +1,72 @@
+package luckydraw
+
+import (
+	"bytes"
+	"sync"
+)
+
+// Store stores save data of draws by name (e.g. in memory, a database or an object storage).
+type Store interface {
+	// Put stores the data with the name. Existing data with the name is replaced.
+	Put(name string, data []byte) error
+	// Get returns the data with the name.
+	// It returns ErrDataNotFound if there's no data with the name.
+	Get(name string) ([]byte, error)
+}
+
+// SaveToStore saves the draw like Save to the store with the name of the draw.
+func (d *Draw) SaveToStore(s Store) error {
+	buf := &bytes.Buffer{}
+	if err := d.Save(buf); err != nil {
+		return err
+	}
+
+	return s.Put(d.Name(), buf.Bytes())
+}
+
+// LoadFromStore loads the draw like Load from the data with the name of the draw in the store.
+func (d *Draw) LoadFromStore(s Store) error {
+	data, err := s.Get(d.Name())
+	if err != nil {
+		return err
+	}
+
+	return d.Load(bytes.NewReader(data))
+}
+
+// MemoryStore is a Store which keeps data in memory
+// (e.g. for tests or environments with read-only file systems).
+// It's safe for concurrent use.
+type MemoryStore struct {
+	mutex *sync.Mutex
+	data  map[string][]byte
+}
+
+// NewMemoryStore creates an empty memory store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		mutex: &sync.Mutex{},
+		data:  make(map[string][]byte),
+	}
+}
+
+// Put stores a copy of the data with the name.
+func (s *MemoryStore) Put(name string, data []byte) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.data[name] = append([]byte{}, data...)
+	return nil
+}
+
+// Get returns a copy of the data with the name.
+func (s *MemoryStore) Get(name string) ([]byte, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	data, ok := s.data[name]
+	if !ok {
+		return nil, ErrDataNotFound
+	}
+	return append([]byte{}, data...), nil
+}
@@ -0,0 +1,65 @@
+package luckydraw
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestMemoryStoreRoundTrip(t *testing.T) {
+	s := NewMemoryStore()
+
+	a := newSeededDraw(t, 42)
+	if _, err := a.Draw(1); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.SaveToStore(s); err != nil {
+		t.Fatal(err)
+	}
+
+	b := New("seed")
+	if err := b.LoadFromStore(s); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(a.AllWinners(), b.AllWinners()) {
+		t.Errorf("winners differ: %v, %v", a.AllWinners(), b.AllWinners())
+	}
+	if !reflect.DeepEqual(a.Participants(), b.Participants()) {
+		t.Errorf("participants differ")
+	}
+}
+
+func TestMemoryStoreCopies(t *testing.T) {
+	s := NewMemoryStore()
+
+	data := []byte("data")
+	if err := s.Put("a", data); err != nil {
+		t.Fatal(err)
+	}
+	data[0] = 'x'
+
+	got, err := s.Get("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "data" {
+		t.Errorf("Get: got %q, want %q", got, "data")
+	}
+	got[0] = 'x'
+
+	if got, _ := s.Get("a"); string(got) != "data" {
+		t.Errorf("Get: got %q after modifying the returned data", got)
+	}
+}
+
+func TestMemoryStoreNotFound(t *testing.T) {
+	s := NewMemoryStore()
+
+	if _, err := s.Get("missing"); !errors.Is(err, ErrDataNotFound) {
+		t.Errorf("Get: got %v, want ErrDataNotFound", err)
+	}
+	if err := New("missing").LoadFromStore(s); !errors.Is(err, ErrDataNotFound) {
+		t.Errorf("LoadFromStore: got %v, want ErrDataNotFound", err)
+	}
+}
@@ -0,0 +1,118 @@
+package luckydraw
+
+import (
+	"sort"
+	"time"
+)
+
+// DrawStratified draws the prize like Draw, but each group wins a number of winners
+// proportional to its size in the available participants.
+// Participants without a group form a group of their own.
+// It returns ErrNoGroups if no available participant has a group.
+//
+// The amount is allocated by the largest remainder method:
+// each group gets the floor of amount * group size / total,
+// then the remaining winners go to the groups with the largest remainders one by one.
+// Ties are broken by group name in ascending order.
+// Winners are drawn within each group and returned in the order of group names.
+// Weights and validators are not applied.
+func (d *Draw) DrawStratified(prizeNo int) (winners []Participant, err error) {
+	defer func() { d.mutated(err) }()
+	defer func() { d.observeDraw(prizeNo, winners, err) }()
+	defer func(start time.Time) {
+		d.logOp("DrawStratified", start, err, "prize_no", prizeNo, "winners", len(winners))
+	}(time.Now())
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.ensureInit()
+
+	if d.finalized {
+		return []Participant{}, ErrFinalized
+	}
+
+	winners = []Participant{}
+
+	if _, ok := d.prizes[prizeNo]; !ok {
+		return winners, ErrPrizeNo
+	}
+
+	prize := d.resolvePercentAmount(prizeNo)
+	amount := prize.Amount
+	if err := checkDrawable(amount); err != nil {
+		return winners, err
+	}
+
+	if _, ok := d.winners[prizeNo]; ok {
+		return winners, ErrWinnersExistBeforeDraw
+	}
+
+	participants := d.availableParticipants(prizeNo)
+	if len(participants) == 0 {
+		return winners, ErrNoAvailableParticipants
+	}
+
+	groups := make(map[string][]Participant)
+	for _, p := range participants {
+		groups[p.Group] = append(groups[p.Group], p)
+	}
+
+	if _, ok := groups[""]; ok && len(groups) == 1 {
+		return winners, ErrNoGroups
+	}
+
+	names := []string{}
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if err := d.checkWinnerCap(); err != nil {
+		return winners, err
+	}
+
+	allocation := allocateStratified(amount, len(participants), names, groups)
+	for _, name := range names {
+		winners = append(winners, draw(d.rnd, allocation[name], groups[name])...)
+	}
+	winners = d.capDistinctWinners(winners)
+
+	d.prizes[prizeNo] = prize
+	d.winners[prizeNo] = copyParticipants(winners)
+	d.indexWinners(prizeNo)
+	d.recordSequence(prizeNo, winners)
+	return winners, nil
+}
+
+// allocateStratified allocates amount to groups by the largest remainder method.
+// names are sorted group names and total is the number of all participants in groups.
+func allocateStratified(amount, total int, names []string, groups map[string][]Participant) map[string]int {
+	if amount > total {
+		amount = total
+	}
+
+	allocation := make(map[string]int)
+	remainders := make(map[string]int)
+	allocated := 0
+	for _, name := range names {
+		size := len(groups[name])
+		allocation[name] = amount * size / total
+		remainders[name] = amount * size % total
+		allocated += allocation[name]
+	}
+
+	// Sort by remainders in descending order. Ties are kept in the order of names.
+	ordered := make([]string, len(names))
+	copy(ordered, names)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return remainders[ordered[i]] > remainders[ordered[j]]
+	})
+
+	for i := 0; allocated < amount; i++ {
+		allocation[ordered[i]]++
+		allocated++
+	}
+
+	return allocation
+}
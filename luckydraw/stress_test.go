@@ -0,0 +1,91 @@
+package luckydraw
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// TestConcurrentDrawAndRead runs draws and reads concurrently, run it with go test -race.
+func TestConcurrentDrawAndRead(t *testing.T) {
+	const prizes = 8
+
+	d := NewWithSeed("stress", 1)
+	for no := 1; no <= prizes; no++ {
+		if err := d.SetPrize(no, fmt.Sprintf("prize %d", no), 3, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i := 0; i < 200; i++ {
+		if err := d.AddParticipant(Participant{ID: fmt.Sprintf("%03d", i), Name: fmt.Sprintf("name %d", i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var wg sync.WaitGroup
+	for no := 1; no <= prizes; no++ {
+		wg.Add(1)
+		go func(no int) {
+			defer wg.Done()
+
+			if _, err := d.Draw(no); err != nil {
+				t.Errorf("Draw(%d): %v", no, err)
+				return
+			}
+			for i := 0; i < 20; i++ {
+				winners := d.Winners(no)
+				if len(winners) == 0 {
+					t.Errorf("Winners(%d): empty", no)
+					return
+				}
+				// Modify the returned slice, it must not change the draw.
+				winners[0].Name = "modified"
+				if _, err := d.Revoke(no, winners[:1]); err != nil {
+					t.Errorf("Revoke(%d): %v", no, err)
+					return
+				}
+				if _, err := d.Redraw(no, 1); err != nil {
+					t.Errorf("Redraw(%d): %v", no, err)
+					return
+				}
+			}
+		}(no)
+	}
+
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for j := 0; j < 50; j++ {
+				for _, p := range d.Participants() {
+					p.Name = "modified"
+				}
+				for no, winners := range d.AllWinners() {
+					for k := range winners {
+						winners[k].Name = "modified"
+					}
+					_ = d.Winners(no)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	for no := 1; no <= prizes; no++ {
+		winners := d.Winners(no)
+		if len(winners) != 3 {
+			t.Errorf("Winners(%d): got %d winners, want 3", no, len(winners))
+		}
+		for _, w := range winners {
+			if w.Name == "modified" {
+				t.Errorf("Winners(%d): internal slice modified by a caller", no)
+			}
+		}
+	}
+	for _, p := range d.Participants() {
+		if p.Name == "modified" {
+			t.Errorf("Participants: internal slice modified by a caller")
+		}
+	}
+}
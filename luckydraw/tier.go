@@ -0,0 +1,95 @@
+package luckydraw
+
+import (
+	"fmt"
+)
+
+// Tier is a sub-tier of a prize.
+type Tier struct {
+	Name   string `json:"name"`
+	Amount int    `json:"amount"`
+}
+
+// TierWinners contains winners of a tier of a prize.
+type TierWinners struct {
+	Tier    Tier          `json:"tier"`
+	Winners []Participant `json:"winners"`
+}
+
+// SetPrizeTiers sets the sub-tiers of the prize in award order (e.g. gold, silver, bronze)
+// and sets the amount of the prize to the sum of the amounts of tiers.
+// Draw fills the tiers in order from the shared pool:
+// the first drawn winners go to the first tier, the following ones to the second tier and so on.
+// Tiers are assigned by rank (see WinnerAtRank), so after a revoke the following winners move up
+// and winners drawn by Redraw fill the last tiers.
+// Each tier amount should be positive. nil removes the tiers and keeps the amount.
+// It returns ErrWinnersExist if the prize has winners.
+func (d *Draw) SetPrizeTiers(prizeNo int, tiers []Tier) (err error) {
+	defer func() { d.mutated(err) }()
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.ensureInit()
+
+	if d.finalized {
+		return ErrFinalized
+	}
+
+	prize, ok := d.prizes[prizeNo]
+	if !ok {
+		return ErrPrizeNo
+	}
+
+	if len(d.winners[prizeNo]) > 0 {
+		return ErrWinnersExist
+	}
+
+	if len(tiers) == 0 {
+		prize.Tiers = nil
+		d.prizes[prizeNo] = prize
+		return nil
+	}
+
+	amount := 0
+	for _, tier := range tiers {
+		if tier.Amount <= 0 {
+			return fmt.Errorf("tier %s: %w", tier.Name, ErrPrizeAmount)
+		}
+		amount += tier.Amount
+	}
+
+	if err := d.checkPrizeAmount(amount); err != nil {
+		return err
+	}
+
+	prize.Tiers = append([]Tier{}, tiers...)
+	prize.Amount = amount
+	d.prizes[prizeNo] = prize
+	return nil
+}
+
+// WinnersByTier returns the winners of the prize grouped by tier in award order.
+// If the prize has no tiers, all winners are in one group with a zero Tier.
+func (d *Draw) WinnersByTier(prizeNo int) []TierWinners {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	winners := d.winners[prizeNo]
+	tiers := d.prizes[prizeNo].Tiers
+	if len(tiers) == 0 {
+		return []TierWinners{{Winners: copyParticipants(winners)}}
+	}
+
+	groups := []TierWinners{}
+	for _, tier := range tiers {
+		n := tier.Amount
+		if n > len(winners) {
+			n = len(winners)
+		}
+		groups = append(groups, TierWinners{tier, copyParticipants(winners[:n])})
+		winners = winners[n:]
+	}
+
+	return groups
+}
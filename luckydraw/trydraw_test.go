@@ -0,0 +1,91 @@
+package luckydraw
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTryDraw(t *testing.T) {
+	d := newSeededDraw(t, 42)
+
+	drawn, winners, err := d.TryDraw(1)
+	if err != nil || !drawn || len(winners) != 3 {
+		t.Fatalf("got %v, %v, %v", drawn, winners, err)
+	}
+
+	// Already drawn.
+	drawn, winners, err = d.TryDraw(1)
+	if err != nil || drawn || winners != nil {
+		t.Errorf("drawn prize: got %v, %v, %v, want false, nil, nil", drawn, winners, err)
+	}
+
+	if _, _, err := d.TryDraw(99); err != ErrPrizeNo {
+		t.Errorf("got %v, want ErrPrizeNo", err)
+	}
+}
+
+func TestTryDrawBusy(t *testing.T) {
+	d := newSeededDraw(t, 42)
+
+	// The validator blocks the first draw of prize 1 until release is closed.
+	validating := make(chan struct{})
+	release := make(chan struct{})
+	first := true
+	d.SetValidator(func(p Participant, prize Prize) bool {
+		if prize.No == 1 && first {
+			first = false
+			close(validating)
+			<-release
+		}
+		return true
+	})
+
+	done := make(chan error)
+	go func() {
+		_, err := d.Draw(1)
+		done <- err
+	}()
+	<-validating
+
+	if !d.DrawInProgress(1) {
+		t.Errorf("DrawInProgress(1): got false")
+	}
+	if d.DrawInProgress(2) {
+		t.Errorf("DrawInProgress(2): got true")
+	}
+
+	drawn, winners, err := d.TryDraw(1)
+	if err != ErrBusy || drawn || winners != nil {
+		t.Errorf("busy: got %v, %v, %v, want false, nil, ErrBusy", drawn, winners, err)
+	}
+
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if d.DrawInProgress(1) {
+		t.Errorf("DrawInProgress(1) after the draw: got true")
+	}
+}
+
+func TestTryDrawWaitsForReaders(t *testing.T) {
+	d := newSeededDraw(t, 42)
+
+	// A reader holding the lock is not a draw in progress.
+	d.mutex.RLock()
+	type result struct {
+		drawn bool
+		err   error
+	}
+	done := make(chan result)
+	go func() {
+		drawn, _, err := d.TryDraw(1)
+		done <- result{drawn, err}
+	}()
+	time.Sleep(10 * time.Millisecond)
+	d.mutex.RUnlock()
+
+	if r := <-done; !r.drawn || r.err != nil {
+		t.Errorf("got %v, %v, want true, nil", r.drawn, r.err)
+	}
+}
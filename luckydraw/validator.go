@@ -0,0 +1,103 @@
+package luckydraw
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Validator approves a picked candidate of the prize before the candidate becomes a winner
+// (e.g. checks an external eligibility service).
+// It returns false to reject the candidate.
+type Validator func(p Participant, prize Prize) bool
+
+// SetValidator sets the validator of the draw.
+// A picked candidate who is rejected by the validator is discarded
+// and another candidate is picked until the winners are full or participants run out.
+// If all picked candidates are rejected, the draw returns ErrAllCandidatesRejected.
+// v is called with the mutex held, so it must not call methods of the draw.
+// nil (default) means no validation.
+// See SetMaxDrawDuration to limit the time of validation.
+func (d *Draw) SetValidator(v Validator) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.validator = v
+}
+
+// selectValidWinners picks winners like pickWinners and discards candidates rejected by the validator.
+// Rejected candidates are removed from the pool and the missing winners are picked again.
+// If no one in the pool can be picked any more (e.g. only participants with zero weight remain),
+// it returns the accepted winners, or the error of pickWinners if no winner is accepted.
+func (d *Draw) selectValidWinners(r *rand.Rand, prize Prize, amount int, participants []Participant, used map[string]bool) ([]Participant, error) {
+	winners := []Participant{}
+	rejected := false
+	start := time.Now()
+
+	pool := make([]Participant, len(participants))
+	copy(pool, participants)
+
+	var exhausted error
+	for len(winners) < amount && len(pool) > 0 {
+		candidates, err := d.pickWinners(r, amount-len(winners), pool, used)
+		if err == ErrNotEnoughParticipants {
+			exhausted = err
+			break
+		}
+		if err != nil {
+			return []Participant{}, err
+		}
+		if len(candidates) == 0 {
+			break
+		}
+
+		picked := make(map[string]bool)
+		for _, p := range candidates {
+			if d.maxDrawDuration > 0 && time.Since(start) > d.maxDrawDuration {
+				return []Participant{}, ErrDrawTimeout
+			}
+
+			picked[p.ID] = true
+			if !d.validator(p, prize) {
+				rejected = true
+				continue
+			}
+
+			winners = append(winners, p)
+			if used != nil && p.Group != "" {
+				used[p.Group] = true
+			}
+		}
+
+		// Remove picked candidates and participants of used groups from the pool.
+		remaining := []Participant{}
+		for _, p := range pool {
+			if picked[p.ID] || (used != nil && p.Group != "" && used[p.Group]) {
+				continue
+			}
+			remaining = append(remaining, p)
+		}
+		pool = remaining
+	}
+
+	if len(winners) == 0 && rejected {
+		return winners, ErrAllCandidatesRejected
+	}
+
+	if len(winners) == 0 && exhausted != nil {
+		return winners, exhausted
+	}
+
+	return winners, nil
+}
+
+// SetMaxDrawDuration sets the max duration of the selection of winners with a validator,
+// so a slow validator (e.g. an external service) which rejects many candidates can't block a draw for long.
+// The elapsed time is checked before each candidate is validated
+// and the draw returns ErrDrawTimeout without recording winners if it's exceeded.
+// 0 (default) means no limit.
+func (d *Draw) SetMaxDrawDuration(dur time.Duration) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.maxDrawDuration = dur
+}
@@ -0,0 +1,66 @@
+package luckydraw
+
+import (
+	"testing"
+)
+
+func TestValidator(t *testing.T) {
+	d := newSeededDraw(t, 42)
+	d.SetValidator(func(p Participant, prize Prize) bool {
+		// Reject odd IDs.
+		return (p.ID[len(p.ID)-1]-'0')%2 == 0
+	})
+
+	winners, err := d.Draw(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(winners) != 3 {
+		t.Errorf("got %d winners, want 3", len(winners))
+	}
+	for _, w := range winners {
+		if (w.ID[len(w.ID)-1]-'0')%2 != 0 {
+			t.Errorf("rejected candidate %s won", w.ID)
+		}
+	}
+}
+
+func TestValidatorRejectsEveryone(t *testing.T) {
+	d := newSeededDraw(t, 42)
+	d.SetValidator(func(p Participant, prize Prize) bool { return false })
+
+	if _, err := d.Draw(1); err != ErrAllCandidatesRejected {
+		t.Errorf("got %v, want ErrAllCandidatesRejected", err)
+	}
+	if winners := d.Winners(1); len(winners) != 0 {
+		t.Errorf("got winners %v", winners)
+	}
+}
+
+func TestValidatorWithZeroWeights(t *testing.T) {
+	d := newSeededDraw(t, 42)
+	// Only 000 and 001 can be picked and 001 is rejected.
+	if err := d.SetWeightFunc(func(p Participant) int {
+		if p.ID == "000" || p.ID == "001" {
+			return 1
+		}
+		return 0
+	}); err != nil {
+		t.Fatal(err)
+	}
+	d.SetValidator(func(p Participant, prize Prize) bool { return p.ID != "001" })
+
+	winners, err := d.Draw(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(winners) != 1 || winners[0].ID != "000" {
+		t.Errorf("got %v, want the accepted winner 000", winners)
+	}
+
+	// Everyone who can be picked is rejected.
+	d.SetValidator(func(p Participant, prize Prize) bool { return false })
+	if _, err := d.Draw(2); err != ErrAllCandidatesRejected {
+		t.Errorf("got %v, want ErrAllCandidatesRejected", err)
+	}
+}
@@ -0,0 +1,35 @@
+package luckydraw
+
+import (
+	"math/rand"
+	"sort"
+)
+
+// VerifyDraw reports if the claimed winners are the winners of a draw with the seed
+// (e.g. a prize drawn by DrawWithSeed), so anyone with the seed and the pool can verify the result
+// without the draw.
+//
+// pool is the available participants of the prize when it was drawn and amount is the prize amount.
+// The draw is recomputed like DrawWithSeed: the pool is sorted by ID,
+// a math/rand generator is seeded with seed and draw selects the winners
+// by a partial Fisher–Yates shuffle (see draw).
+// The claimed winners must match the recomputed winners by ID in pick order.
+// Draws with weights, unique groups, validators or raffle mode can't be verified by VerifyDraw.
+func VerifyDraw(seed int64, pool []Participant, amount int, claimedWinners []Participant) bool {
+	participants := copyParticipants(pool)
+	sort.Slice(participants, func(i, j int) bool {
+		return participants[i].ID < participants[j].ID
+	})
+
+	winners := draw(rand.New(rand.NewSource(seed)), amount, participants)
+	if len(winners) != len(claimedWinners) {
+		return false
+	}
+
+	for i, winner := range winners {
+		if winner.ID != claimedWinners[i].ID {
+			return false
+		}
+	}
+	return true
+}
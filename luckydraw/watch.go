@@ -0,0 +1,122 @@
+package luckydraw
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// watchBufferSize is the buffer size of the event channels of WatchWinners.
+const watchBufferSize = 64
+
+// DrawEvent is a change of the winners of a prize (e.g. drawn, redrawn, revoked or cleared).
+type DrawEvent struct {
+	PrizeNo int `json:"prize_no"`
+	// Winners are all winners of the prize after the change in draw order.
+	// It's empty if the winners are cleared.
+	Winners []Participant `json:"winners"`
+	At      time.Time     `json:"at"`
+}
+
+// WatchWinners returns the current winners and a channel of the following changes of winners
+// (e.g. to stream winners to a web UI via Server-Sent Events).
+// The snapshot and the subscription are taken atomically:
+// each change after the snapshot is sent exactly once and no change before it is sent.
+// Each change of a prize is sent as one event, and changes of several prizes by one operation are sent by prize no.
+// The channel is buffered. If the receiver falls behind and the buffer is full,
+// the channel is closed and it should call WatchWinners again to resync.
+// cancel stops watching and closes the channel. It's safe to call cancel more than once.
+func (d *Draw) WatchWinners() (initial WinnersResult, events <-chan DrawEvent, cancel func()) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.ensureInit()
+
+	if d.watchers == nil {
+		d.watchers = make(map[int]chan DrawEvent)
+	}
+
+	// Without watchers, the published winners are not tracked, so start from the current winners.
+	if len(d.watchers) == 0 {
+		d.watchedWinners = copyWinners(d.winners)
+	}
+
+	id := d.nextWatcherID
+	d.nextWatcherID++
+	ch := make(chan DrawEvent, watchBufferSize)
+	d.watchers[id] = ch
+
+	once := sync.Once{}
+	cancel = func() {
+		once.Do(func() {
+			d.mutex.Lock()
+			defer d.mutex.Unlock()
+
+			// The channel is closed already if the watcher is dropped.
+			if c, ok := d.watchers[id]; ok && c == ch {
+				delete(d.watchers, id)
+				close(ch)
+			}
+		})
+	}
+
+	return newWinnersResult(d.watchedWinners), ch, cancel
+}
+
+// publishWinners sends the changes of winners since the last call to the watchers.
+// The caller should hold the mutex.
+func (d *Draw) publishWinners() {
+	if len(d.watchers) == 0 {
+		d.watchedWinners = nil
+		return
+	}
+
+	prizeNos := []int{}
+	for prizeNo, winners := range d.winners {
+		if !sameWinners(winners, d.watchedWinners[prizeNo]) {
+			prizeNos = append(prizeNos, prizeNo)
+		}
+	}
+	for prizeNo := range d.watchedWinners {
+		if _, ok := d.winners[prizeNo]; !ok {
+			prizeNos = append(prizeNos, prizeNo)
+		}
+	}
+	sort.Ints(prizeNos)
+
+	now := d.now()
+	for _, prizeNo := range prizeNos {
+		for id, ch := range d.watchers {
+			select {
+			case ch <- DrawEvent{prizeNo, copyParticipants(d.winners[prizeNo]), now}:
+			default:
+				// Drop the watcher which falls behind instead of blocking the draw.
+				delete(d.watchers, id)
+				close(ch)
+			}
+		}
+	}
+
+	d.watchedWinners = copyWinners(d.winners)
+}
+
+// sameWinners reports whether the winners are the same in the same order.
+func sameWinners(a, b []Participant) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].ID != b[i].ID {
+			return false
+		}
+	}
+	return true
+}
+
+func copyWinners(m map[int][]Participant) map[int][]Participant {
+	copied := make(map[int][]Participant)
+	for prizeNo, winners := range m {
+		copied[prizeNo] = copyParticipants(winners)
+	}
+	return copied
+}
@@ -0,0 +1,256 @@
+package luckydraw
+
+import (
+	"math"
+	"math/rand"
+)
+
+// WeightFunc returns the weight of a participant for weighted draws.
+// A participant with weight 2 is twice as likely to be picked as one with weight 1.
+// Participants with weight <= 0 are never picked.
+type WeightFunc func(p Participant) int
+
+// SetWeightFunc sets the weight function of the draw.
+// Weights are computed from participants when drawing (e.g. derived from Group),
+// so they're not saved in the data file.
+// nil (default) makes all participants have the same chance.
+// f is called with the mutex held, so it must not call methods of the draw.
+// It returns ErrFinalized if the draw is finalized.
+func (d *Draw) SetWeightFunc(f WeightFunc) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.finalized {
+		return ErrFinalized
+	}
+
+	d.weightFunc = f
+	return nil
+}
+
+// drawWeighted selects up to prizeAmount winners from participants by their weights.
+//
+// Weights are integers, so there's no floating point rounding:
+// for each winner, it sums the weights of the remaining candidates,
+// picks a single uniform integer n in [0, total)
+// and selects the first candidate whose cumulative weight is greater than n.
+// The selected candidate is removed and it repeats until the winners are full
+// or no candidate with a positive weight remains.
+// Candidates are visited in the order of participants,
+// so results are exactly reproducible under a fixed seed.
+//
+// It returns ErrNotEnoughParticipants if no participant has a positive weight.
+// If usedGroups is not nil, it picks at most one winner from each group like drawUniqueGroups
+// and returns ErrConstraintUnsatisfiable if there're not enough groups to fill the winners.
+func drawWeighted(r *rand.Rand, prizeAmount int, participants []Participant, weight WeightFunc, usedGroups map[string]bool) ([]Participant, error) {
+	winners := []Participant{}
+
+	pool := []Participant{}
+	weights := []int64{}
+	for _, p := range participants {
+		if w := weight(p); w > 0 {
+			pool = append(pool, p)
+			weights = append(weights, int64(w))
+		}
+	}
+
+	if len(pool) == 0 && prizeAmount > 0 {
+		return winners, ErrNotEnoughParticipants
+	}
+
+	discarded := false
+	groups := make(map[string]bool)
+	for group := range usedGroups {
+		groups[group] = true
+	}
+
+	for len(winners) < prizeAmount && len(pool) > 0 {
+		var total int64
+		for _, w := range weights {
+			total += w
+		}
+
+		n := r.Int63n(total)
+		i := 0
+		for ; i < len(weights)-1; i++ {
+			if n < weights[i] {
+				break
+			}
+			n -= weights[i]
+		}
+
+		p := pool[i]
+		pool = append(pool[:i], pool[i+1:]...)
+		weights = append(weights[:i], weights[i+1:]...)
+
+		if usedGroups != nil && p.Group != "" {
+			if groups[p.Group] {
+				discarded = true
+				continue
+			}
+			groups[p.Group] = true
+		}
+
+		winners = append(winners, p)
+	}
+
+	if discarded && len(winners) < prizeAmount {
+		return []Participant{}, ErrConstraintUnsatisfiable
+	}
+
+	return winners, nil
+}
+
+// entries returns the number of entries of the participant.
+func entries(p Participant) int {
+	if p.Entries < 1 {
+		return 1
+	}
+	return p.Entries
+}
+
+// effectiveWeightFunc returns the weight function with the recency penalty and entries of participants applied.
+// It returns nil if all participants have the same chance,
+// so draws without weights, penalties or entries are not changed.
+// The caller should hold the mutex.
+func (d *Draw) effectiveWeightFunc(participants []Participant) WeightFunc {
+	weight := d.weightFuncWithPenalty()
+
+	multiple := false
+	for _, p := range participants {
+		if p.Entries > 1 {
+			multiple = true
+			break
+		}
+	}
+
+	if !multiple {
+		return weight
+	}
+
+	return func(p Participant) int {
+		w := 1
+		if weight != nil {
+			w = weight(p)
+		}
+		return w * entries(p)
+	}
+}
+
+// ExpectedWins returns the expected number of winners of each segment (e.g. region, tier) of the prize
+// with the current weights, so the intended distribution can be checked before the draw.
+// segmentOf returns the segment of a participant and it's called with the mutex held.
+// It's computed analytically from the available participants and the remaining slots of the prize.
+// Without weights, each participant wins with probability slots / participants.
+// With weights, winners are picked one by one without replacement and the probability of a participant
+// is approximated by successive sampling: 1 - exp(-w*t), where t makes the probabilities sum to the slots.
+// In raffle mode, tickets are drawn with replacement and a participant is expected to win slots * tickets / total tickets.
+// Unique groups and the winner validator are not considered.
+func (d *Draw) ExpectedWins(prizeNo int, segmentOf func(Participant) string) map[string]float64 {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	expected := make(map[string]float64)
+
+	prize, ok := d.prizes[prizeNo]
+	if !ok {
+		return expected
+	}
+
+	slots := d.effectiveAmount(prize) - len(d.winners[prizeNo])
+	participants := d.availableParticipants(prizeNo)
+	if slots <= 0 || len(participants) == 0 {
+		return expected
+	}
+
+	weights := make([]float64, len(participants))
+	if d.raffle {
+		for i, p := range participants {
+			weights[i] = float64(d.ticketsOf(p.ID))
+		}
+	} else {
+		weight := d.effectiveWeightFunc(participants)
+		for i, p := range participants {
+			weights[i] = 1
+			if weight != nil {
+				weights[i] = float64(weight(p))
+			}
+		}
+	}
+
+	probs := inclusionProbabilities(weights, slots, d.raffle)
+	for i, p := range participants {
+		expected[segmentOf(p)] += probs[i]
+	}
+
+	return expected
+}
+
+// inclusionProbabilities returns the expected number of times each item is picked
+// when n items are picked by weights with or without replacement.
+// Items with non-positive weights are never picked.
+func inclusionProbabilities(weights []float64, n int, replacement bool) []float64 {
+	probs := make([]float64, len(weights))
+
+	var total float64
+	positive := 0
+	for _, w := range weights {
+		if w > 0 {
+			total += w
+			positive++
+		}
+	}
+	if positive == 0 {
+		return probs
+	}
+
+	if replacement {
+		for i, w := range weights {
+			if w > 0 {
+				probs[i] = float64(n) * w / total
+			}
+		}
+		return probs
+	}
+
+	// All items with positive weights are picked.
+	if n >= positive {
+		for i, w := range weights {
+			if w > 0 {
+				probs[i] = 1
+			}
+		}
+		return probs
+	}
+
+	// Find t by bisection so the sum of 1 - exp(-w*t) is n. The sum increases with t.
+	sum := func(t float64) float64 {
+		var s float64
+		for _, w := range weights {
+			if w > 0 {
+				s += -math.Expm1(-w * t)
+			}
+		}
+		return s
+	}
+
+	lo, hi := 0.0, 1/total
+	for sum(hi) < float64(n) {
+		hi *= 2
+	}
+	for i := 0; i < 100; i++ {
+		mid := (lo + hi) / 2
+		if sum(mid) < float64(n) {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+
+	for i, w := range weights {
+		if w > 0 {
+			probs[i] = -math.Expm1(-w * hi)
+		}
+	}
+	return probs
+}
@@ -0,0 +1,97 @@
+package luckydraw
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestDrawWeightedReproducible(t *testing.T) {
+	participants := []Participant{{ID: "a", Group: "x"}, {ID: "b", Group: "y"}, {ID: "c", Group: "y"}, {ID: "d"}}
+	weight := func(p Participant) int {
+		if p.Group == "y" {
+			return 3
+		}
+		return 1
+	}
+
+	a, err := drawWeighted(rand.New(rand.NewSource(7)), 3, participants, weight, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := drawWeighted(rand.New(rand.NewSource(7)), 3, participants, weight, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(a, b) {
+		t.Errorf("winners with the same seed differ: %v, %v", a, b)
+	}
+}
+
+func TestDrawWeightedDistribution(t *testing.T) {
+	participants := []Participant{{ID: "a"}, {ID: "b"}, {ID: "c"}}
+	weights := map[string]int{"a": 1, "b": 3, "c": 0}
+	weight := func(p Participant) int { return weights[p.ID] }
+
+	const iterations = 20000
+	r := rand.New(rand.NewSource(1))
+	counts := make(map[string]int)
+	for i := 0; i < iterations; i++ {
+		winners, err := drawWeighted(r, 1, participants, weight, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		counts[winners[0].ID]++
+	}
+
+	// b is picked 3 times as often as a and c is never picked.
+	if counts["c"] != 0 {
+		t.Errorf("c with weight 0 picked %d times", counts["c"])
+	}
+	if ratio := float64(counts["b"]) / float64(counts["a"]); ratio < 2.7 || ratio > 3.3 {
+		t.Errorf("got ratio %.2f of b to a, want 3: %v", ratio, counts)
+	}
+}
+
+func TestDrawWeightedZeroWeights(t *testing.T) {
+	participants := []Participant{{ID: "a"}, {ID: "b"}}
+
+	winners, err := drawWeighted(rand.New(rand.NewSource(1)), 2, participants, func(p Participant) int {
+		if p.ID == "a" {
+			return 1
+		}
+		return 0
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(winners) != 1 || winners[0].ID != "a" {
+		t.Errorf("got %v, want only a", winners)
+	}
+
+	if _, err := drawWeighted(rand.New(rand.NewSource(1)), 1, participants, func(Participant) int { return 0 }, nil); err != ErrNotEnoughParticipants {
+		t.Errorf("all zero weights: got %v, want ErrNotEnoughParticipants", err)
+	}
+}
+
+func TestSetWeightFunc(t *testing.T) {
+	d := newSeededDraw(t, 42)
+	if err := d.SetWeightFunc(func(p Participant) int {
+		if p.ID < "010" {
+			return 1
+		}
+		return 0
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	winners, err := d.Draw(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, w := range winners {
+		if w.ID >= "010" {
+			t.Errorf("participant %s with weight 0 won", w.ID)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package luckydraw
+
+// SetMaxDistinctWinners sets the max number of distinct participants who can win in the event
+// (e.g. 50 winners in total however they're spread across prizes).
+// Once the cap is reached, draws return ErrWinnerCapReached and record nothing.
+// A draw which would exceed the cap only records new winners up to the cap,
+// and winners who already won another prize (multi-win) don't count again.
+// 0 (default) means no cap.
+func (d *Draw) SetMaxDistinctWinners(n int) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.maxDistinctWinners = n
+}
+
+// distinctWinners returns IDs of all winners.
+// The caller should hold the mutex.
+func (d *Draw) distinctWinners() map[string]bool {
+	ids := make(map[string]bool)
+	for _, winners := range d.winners {
+		for _, winner := range winners {
+			ids[winner.ID] = true
+		}
+	}
+	return ids
+}
+
+// checkWinnerCap returns ErrWinnerCapReached if the max number of distinct winners is reached.
+// The caller should hold the mutex.
+func (d *Draw) checkWinnerCap() error {
+	if d.maxDistinctWinners > 0 && len(d.distinctWinners()) >= d.maxDistinctWinners {
+		return ErrWinnerCapReached
+	}
+	return nil
+}
+
+// capDistinctWinners drops new winners which would exceed the max number of distinct winners.
+// The caller should hold the mutex.
+func (d *Draw) capDistinctWinners(winners []Participant) []Participant {
+	if d.maxDistinctWinners <= 0 {
+		return winners
+	}
+
+	won := d.distinctWinners()
+	capped := []Participant{}
+	for _, winner := range winners {
+		if !won[winner.ID] {
+			if len(won) >= d.maxDistinctWinners {
+				continue
+			}
+			won[winner.ID] = true
+		}
+		capped = append(capped, winner)
+	}
+	return capped
+}
@@ -0,0 +1,46 @@
+package luckydraw
+
+// indexWinners updates the winner index (see IsWinner) with the current winners of the prize.
+// It must be called after the winners of the prize are changed.
+// The caller should hold the mutex.
+func (d *Draw) indexWinners(prizeNo int) {
+	if d.winnerIndex == nil {
+		d.winnerIndex = make(map[int]map[string]bool)
+	}
+	if d.winCounts == nil {
+		d.winCounts = make(map[string]int)
+	}
+
+	for id := range d.winnerIndex[prizeNo] {
+		if d.winCounts[id]--; d.winCounts[id] <= 0 {
+			delete(d.winCounts, id)
+		}
+	}
+	delete(d.winnerIndex, prizeNo)
+
+	winners := d.winners[prizeNo]
+	if len(winners) == 0 {
+		return
+	}
+
+	// An ID may win the prize more than once in raffle mode, but it's counted once per prize.
+	ids := make(map[string]bool, len(winners))
+	for _, winner := range winners {
+		ids[winner.ID] = true
+	}
+	for id := range ids {
+		d.winCounts[id]++
+	}
+	d.winnerIndex[prizeNo] = ids
+}
+
+// reindexWinners rebuilds the winner index from all winners.
+// It must be called after winners are replaced (e.g. by Load).
+// The caller should hold the mutex.
+func (d *Draw) reindexWinners() {
+	d.winnerIndex = make(map[int]map[string]bool)
+	d.winCounts = make(map[string]int)
+	for prizeNo := range d.winners {
+		d.indexWinners(prizeNo)
+	}
+}
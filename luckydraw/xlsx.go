@@ -0,0 +1,301 @@
+package luckydraw
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// LoadXLSX loads prizes and participants from 2 sheets of an XLSX workbook.
+// The sheets have the same columns as the CSV files of LoadPrizesCSV and LoadParticipantsCSV.
+// Both sheets are read and parsed first, then prizes and participants are replaced together under a single lock,
+// so nothing is loaded if either sheet fails.
+// If the sum of prize amounts is greater than the number of participants, a warning is logged, see SetLogger.
+func (d *Draw) LoadXLSX(r io.Reader, prizeSheet, participantSheet string) (err error) {
+	defer func() { d.mutated(err) }()
+
+	buf, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf), int64(len(buf)))
+	if err != nil {
+		return err
+	}
+
+	sheets, err := readXLSXSheets(zr, prizeSheet, participantSheet)
+	if err != nil {
+		return err
+	}
+
+	prizesCSV, err := rowsToCSV(sheets[prizeSheet])
+	if err != nil {
+		return err
+	}
+
+	participantsCSV, err := rowsToCSV(sheets[participantSheet])
+	if err != nil {
+		return err
+	}
+
+	d.mutex.Lock()
+	err = d.loadXLSXSheets(prizesCSV, participantsCSV, prizeSheet, participantSheet)
+	slots, participants, logger := d.totalSlots(), len(d.participants), d.logger
+	d.mutex.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	if slots > participants && logger != nil {
+		logger.Warn("LoadXLSX: prize amounts exceed participants",
+			"slots", slots, "participants", participants)
+	}
+
+	return nil
+}
+
+// loadXLSXSheets parses the prizes and participants CSV converted from the sheets
+// and replaces prizes and participants only if both are valid.
+// The caller should hold the mutex.
+func (d *Draw) loadXLSXSheets(prizesCSV, participantsCSV io.Reader, prizeSheet, participantSheet string) error {
+	d.ensureInit()
+
+	if d.finalized {
+		return ErrFinalized
+	}
+
+	if d.participantsLocked() {
+		return ErrParticipantsLocked
+	}
+
+	if d.registrationClosed() {
+		return ErrRegistrationClosed
+	}
+
+	prizes, err := d.parsePrizesCSV(prizesCSV, ',', nil)
+	if err != nil {
+		return fmt.Errorf("sheet %s: %w", prizeSheet, err)
+	}
+
+	participants, tickets, err := d.parseParticipantsCSV(participantsCSV, ',')
+	if err != nil {
+		return fmt.Errorf("sheet %s: %w", participantSheet, err)
+	}
+
+	d.prizes = prizes
+	d.participants = participants
+	d.tickets = tickets
+	return nil
+}
+
+func (d *Draw) LoadXLSXFile(file, prizeSheet, participantSheet string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return d.LoadXLSX(f, prizeSheet, participantSheet)
+}
+
+// rowsToCSV writes rows as CSV. Short rows are padded to the width of the header.
+func rowsToCSV(rows [][]string) (io.Reader, error) {
+	buf := &bytes.Buffer{}
+	w := csv.NewWriter(buf)
+
+	width := 0
+	if len(rows) > 0 {
+		width = len(rows[0])
+	}
+
+	for _, row := range rows {
+		for len(row) < width {
+			row = append(row, "")
+		}
+		if err := w.Write(row[:width]); err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+	return buf, w.Error()
+}
+
+type xlsxWorkbook struct {
+	Sheets []struct {
+		Name string `xml:"name,attr"`
+		ID   string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type xlsxRelationships struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+type xlsxSharedStrings struct {
+	Items []struct {
+		T string `xml:"t"`
+		R []struct {
+			T string `xml:"t"`
+		} `xml:"r"`
+	} `xml:"si"`
+}
+
+type xlsxSheet struct {
+	Rows []struct {
+		Cells []struct {
+			Ref    string `xml:"r,attr"`
+			Type   string `xml:"t,attr"`
+			Value  string `xml:"v"`
+			Inline struct {
+				T string `xml:"t"`
+			} `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// readXLSXSheets reads the rows of the named sheets of the XLSX workbook.
+func readXLSXSheets(zr *zip.Reader, names ...string) (map[string][][]string, error) {
+	files := make(map[string]*zip.File)
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	workbook := xlsxWorkbook{}
+	if err := readXLSXFile(files, "xl/workbook.xml", &workbook); err != nil {
+		return nil, err
+	}
+
+	rels := xlsxRelationships{}
+	if err := readXLSXFile(files, "xl/_rels/workbook.xml.rels", &rels); err != nil {
+		return nil, err
+	}
+
+	// Shared strings are optional.
+	shared := xlsxSharedStrings{}
+	if _, ok := files["xl/sharedStrings.xml"]; ok {
+		if err := readXLSXFile(files, "xl/sharedStrings.xml", &shared); err != nil {
+			return nil, err
+		}
+	}
+
+	strs := []string{}
+	for _, item := range shared.Items {
+		s := item.T
+		for _, r := range item.R {
+			s += r.T
+		}
+		strs = append(strs, s)
+	}
+
+	targets := make(map[string]string)
+	for _, rel := range rels.Relationships {
+		target := strings.TrimPrefix(rel.Target, "/")
+		if !strings.HasPrefix(target, "xl/") {
+			target = path.Join("xl", target)
+		}
+		targets[rel.ID] = target
+	}
+
+	sheetFiles := make(map[string]string)
+	for _, sheet := range workbook.Sheets {
+		sheetFiles[sheet.Name] = targets[sheet.ID]
+	}
+
+	sheets := make(map[string][][]string)
+	for _, name := range names {
+		file, ok := sheetFiles[name]
+		if !ok {
+			return nil, fmt.Errorf("sheet %s not found", name)
+		}
+
+		sheet := xlsxSheet{}
+		if err := readXLSXFile(files, file, &sheet); err != nil {
+			return nil, err
+		}
+
+		rows := [][]string{}
+		for _, r := range sheet.Rows {
+			row := []string{}
+			for i, c := range r.Cells {
+				col := i
+				if c.Ref != "" {
+					var ok bool
+					if col, ok = xlsxColumn(c.Ref); !ok {
+						return nil, fmt.Errorf("sheet %s: cell %s: incorrect cell reference", name, c.Ref)
+					}
+				}
+				for len(row) < col {
+					row = append(row, "")
+				}
+
+				v := c.Value
+				switch c.Type {
+				case "s":
+					n, err := strconv.Atoi(v)
+					if err != nil || n < 0 || n >= len(strs) {
+						return nil, fmt.Errorf("sheet %s: cell %s: incorrect shared string", name, c.Ref)
+					}
+					v = strs[n]
+				case "inlineStr":
+					v = c.Inline.T
+				}
+				row = append(row, v)
+			}
+			rows = append(rows, row)
+		}
+		sheets[name] = rows
+	}
+
+	return sheets, nil
+}
+
+func readXLSXFile(files map[string]*zip.File, name string, v interface{}) error {
+	f, ok := files[name]
+	if !ok {
+		return fmt.Errorf("%s not found in XLSX", name)
+	}
+
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	return xml.NewDecoder(rc).Decode(v)
+}
+
+// xlsxMaxColumns is the max number of columns of an XLSX sheet (column XFD).
+const xlsxMaxColumns = 16384
+
+// xlsxColumn returns the 0-based column index of the cell reference (e.g. "B3" is 1).
+// It returns false if the reference has no column or the column is over xlsxMaxColumns,
+// so a crafted reference (e.g. "ZZZZZZ1") can't make a huge row.
+func xlsxColumn(ref string) (int, bool) {
+	col := 0
+	for _, c := range ref {
+		if c < 'A' || c > 'Z' {
+			break
+		}
+		col = col*26 + int(c-'A'+1)
+		if col > xlsxMaxColumns {
+			return 0, false
+		}
+	}
+	if col == 0 {
+		return 0, false
+	}
+	return col - 1, true
+}
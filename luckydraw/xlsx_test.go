@@ -0,0 +1,105 @@
+package luckydraw
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// makeXLSX makes a minimal XLSX workbook with the sheets of inline string cells.
+// Each cell is a reference and a value separated by '=' (e.g. "A1=id").
+func makeXLSX(t *testing.T, sheets map[string][][]string) []byte {
+	t.Helper()
+
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+	write := func(name, content string) {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	workbook := `<workbook xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`
+	rels := `<Relationships>`
+	i := 0
+	for name, rows := range sheets {
+		i++
+		workbook += fmt.Sprintf(`<sheet name="%s" r:id="rId%d"/>`, name, i)
+		rels += fmt.Sprintf(`<Relationship Id="rId%d" Target="worksheets/sheet%d.xml"/>`, i, i)
+
+		sheet := `<worksheet><sheetData>`
+		for _, row := range rows {
+			sheet += `<row>`
+			for _, cell := range row {
+				ref, v, _ := strings.Cut(cell, "=")
+				sheet += fmt.Sprintf(`<c r="%s" t="inlineStr"><is><t>%s</t></is></c>`, ref, v)
+			}
+			sheet += `</row>`
+		}
+		sheet += `</sheetData></worksheet>`
+		write(fmt.Sprintf("xl/worksheets/sheet%d.xml", i), sheet)
+	}
+	write("xl/workbook.xml", workbook+`</sheets></workbook>`)
+	write("xl/_rels/workbook.xml.rels", rels+`</Relationships>`)
+
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+var xlsxPrizes = [][]string{
+	{"A1=no", "B1=name", "C1=amount", "D1=desc"},
+	{"A2=1", "B2=prize 1", "C2=1", "D2="},
+}
+
+func TestLoadXLSX(t *testing.T) {
+	data := makeXLSX(t, map[string][][]string{
+		"prizes": xlsxPrizes,
+		"participants": {
+			{"A1=id", "B1=name"},
+			{"A2=001", "B2=Alice"},
+			// The name cell is omitted.
+			{"A3=002"},
+		},
+	})
+
+	d := New("xlsx")
+	if err := d.LoadXLSX(bytes.NewReader(data), "prizes", "participants"); err != nil {
+		t.Fatal(err)
+	}
+	if got := d.Participants(); len(got) != 2 || got[0].Name != "Alice" || got[1].Name != "" {
+		t.Errorf("participants: got %v", got)
+	}
+	if got := d.Prizes(false); len(got) != 1 || got[0].Amount != 1 {
+		t.Errorf("prizes: got %v", got)
+	}
+}
+
+func TestLoadXLSXColumnLimit(t *testing.T) {
+	for _, ref := range []string{"ZZZZZZ2", "XFE2", "2"} {
+		data := makeXLSX(t, map[string][][]string{
+			"prizes": xlsxPrizes,
+			"participants": {
+				{"A1=id", "B1=name"},
+				{"A2=001", ref + "=x"},
+			},
+		})
+
+		d := New("xlsx")
+		if err := d.LoadXLSX(bytes.NewReader(data), "prizes", "participants"); err == nil || !strings.Contains(err.Error(), "incorrect cell reference") {
+			t.Errorf("%s: got %v, want incorrect cell reference", ref, err)
+		}
+	}
+
+	// The last column is accepted.
+	if col, ok := xlsxColumn("XFD1"); !ok || col != xlsxMaxColumns-1 {
+		t.Errorf("XFD1: got %d, %v", col, ok)
+	}
+}